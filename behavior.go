@@ -1,6 +1,11 @@
 // Package bt is a minimalist implementation of a behavior tree.
 package bt
 
+import (
+	"context"
+	"math/rand"
+)
+
 // State describes the outcome of running a Behavior.
 type State int
 
@@ -12,17 +17,22 @@ func (s State) String() string {
 		return "Success"
 	case Failure:
 		return "Failure"
+	case Cancelled:
+		return "Cancelled"
 	default:
 		return "Unknown"
 	}
 }
 
-// State constants to be used by Behavior.
+// State constants to be used by Behavior. Cancelled is returned in place of a
+// Behavior which was not run because its Context was done, and like Unknown,
+// composites stop and pass it up as soon as a child returns it.
 const (
 	Unknown State = iota
 	Running
 	Success
 	Failure
+	Cancelled
 )
 
 // Behavior is a node in a behavior tree.
@@ -31,6 +41,45 @@ type Behavior interface {
 	Execute() State
 }
 
+// CtxBehavior is a Behavior which can also be executed with a Context.
+type CtxBehavior interface {
+	Behavior
+	ExecuteCtx(ctx context.Context) State
+}
+
+// Execute runs b with ctx if b is a CtxBehavior, or without ctx otherwise.
+// Composites and decorators use Execute to run their children, so once ctx is
+// done, no further children are run and Cancelled is returned in their place.
+// Likewise, once the time budget of RunWithin is spent, no further children are
+// run and Cancelled is returned in their place, so that composites stop where
+// they are without mistaking the unrun children for Running ones; RunWithin
+// reports Running for the whole tree instead.
+//
+// Cancellation does not Reset anything: a cancelled subtree keeps its progress,
+// and executing it again resumes from the child which was not run. Call Reset
+// before executing again to start over instead.
+func Execute(ctx context.Context, b Behavior) State {
+	if ctx.Err() != nil {
+		return Cancelled
+	}
+	if bg, ok := ctx.Value(budgetKey{}).(*budget); ok && bg.exceeded() {
+		return Cancelled
+	}
+	var s State
+	if c, ok := b.(CtxBehavior); ok {
+		s = c.ExecuteCtx(ctx)
+	} else {
+		s = b.Execute()
+	}
+	if r, ok := b.(stateSetter); ok {
+		r.setState(s)
+	}
+	if tracer != nil {
+		tracer(b, s)
+	}
+	return s
+}
+
 // Action is a function which acts as a Behavior.
 type Action func() State
 
@@ -40,6 +89,19 @@ func (Action) Reset() {}
 // Execute calls the underlying function and returns the result.
 func (a Action) Execute() State { return a() }
 
+// ActionCtx is a function of a Context which acts as a Behavior.
+type ActionCtx func(context.Context) State
+
+// Reset is a noop.
+func (ActionCtx) Reset() {}
+
+// Execute calls the underlying function with a background Context and returns
+// the result.
+func (a ActionCtx) Execute() State { return a(context.Background()) }
+
+// ExecuteCtx calls the underlying function with ctx and returns the result.
+func (a ActionCtx) ExecuteCtx(ctx context.Context) State { return a(ctx) }
+
 // Func is a function which acts as a Behavior which always suceeds.
 type Func func()
 
@@ -66,26 +128,110 @@ func (c Conditional) Execute() State {
 	return Failure
 }
 
+// ConditionalCtx is a bool function of a Context which acts as a Behavior, so
+// that a condition can inspect request-scoped values or the deadline of the
+// Context it is executed with. ExecuteCtx always calls the function, but once
+// the Context is done, the package Execute, which composites and decorators use
+// to run their children, returns Cancelled without calling it at all.
+type ConditionalCtx func(context.Context) bool
+
+// Reset is a noop.
+func (ConditionalCtx) Reset() {}
+
+// Execute calls the function with a background Context, returning Success if
+// true, or Failure otherwise.
+func (c ConditionalCtx) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx calls the function with ctx, returning Success if true, or
+// Failure otherwise.
+func (c ConditionalCtx) ExecuteCtx(ctx context.Context) State {
+	if c(ctx) {
+		return Success
+	}
+	return Failure
+}
+
+// And gets a Conditional which is the short-circuit conjunction of the given
+// Conditional. With no Conditional, it is always true.
+func And(cs ...Conditional) Conditional {
+	return func() bool {
+		for _, c := range cs {
+			if !c() {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or gets a Conditional which is the short-circuit disjunction of the given
+// Conditional. With no Conditional, it is always false.
+func Or(cs ...Conditional) Conditional {
+	return func() bool {
+		for _, c := range cs {
+			if c() {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // composite is the base of a Behavior composed of other Behavior.
 type composite struct {
 	nodes []Behavior
 	index int
+	state State
 }
 
 // Reset moves the index to 0 and resets all child Behavior.
 func (c *composite) Reset() {
 	c.index = 0
+	c.state = Unknown
 	for _, n := range c.nodes {
 		n.Reset()
 	}
 }
 
+// iterate runs each child Behavior in order, starting from the current index.
+// A child which succeeds moves on to the next child if onSuccess is true, and
+// otherwise stops the iteration with Success; likewise for a child which fails
+// and onFailure. A child which is Running, Cancelled or Unknown always stops
+// the iteration, leaving the index at that child. If every child is passed,
+// done is returned.
+func (c *composite) iterate(ctx context.Context, onSuccess, onFailure bool, done State) State {
+	for ; c.index < len(c.nodes); c.index++ {
+		switch s := Execute(ctx, c.nodes[c.index]); s {
+		case Success:
+			if !onSuccess {
+				return s
+			}
+		case Failure:
+			if !onFailure {
+				return s
+			}
+		case Running:
+			return Running
+		case Cancelled:
+			return Cancelled
+		default:
+			return Unknown
+		}
+	}
+	return done
+}
+
 // sequence is a Behavior which is the conjunction of child Behavior.
 type sequence struct {
 	composite
 }
 
-// Sequence gets a Behavior with the conjunction of child Beheavior.
+// Sequence gets a Behavior with the conjunction of child Beheavior. The
+// sequence remembers its progress until Reset, even once complete, so executing
+// it again after a Failure resumes from the child which failed. Use AutoReset
+// to start over automatically instead. With no children, it always succeeds.
 func Sequence(bs ...Behavior) Behavior {
 	return &sequence{composite{nodes: bs}}
 }
@@ -93,14 +239,270 @@ func Sequence(bs ...Behavior) Behavior {
 // Execute runs each child Behavior in sequence. It succeeds if all the child
 // Behavior suceceed, but immediately fails if any child fails.
 func (s *sequence) Execute() State {
-	for ; s.index < len(s.nodes); s.index++ {
-		switch s.nodes[s.index].Execute() {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *sequence) ExecuteCtx(ctx context.Context) State {
+	return s.iterate(ctx, true, false, Success)
+}
+
+// selection is a Behavior which is the disjunction of child Behavior.
+type selection struct {
+	composite
+}
+
+// Selection gets a Behavior with the disjunction of child Beheavior. With no
+// children, it always fails.
+func Selection(bs ...Behavior) Behavior {
+	return &selection{composite{nodes: bs}}
+}
+
+// SelectionOr gets a Behavior like Selection, except that if every child
+// fails, fallback is run and its result returned instead of Failure. Since the
+// fallback is simply the last child, it is only run once the others have
+// failed in the current activation, and it is reset along with them. It is
+// equivalent to Selection with fallback appended to bs.
+func SelectionOr(fallback Behavior, bs ...Behavior) Behavior {
+	nodes := make([]Behavior, 0, len(bs)+1)
+	return Selection(append(append(nodes, bs...), fallback)...)
+}
+
+// Execute runs each child Behavior in sequence. It immediately succeeds if any
+// the child Behavior suceceed, but fails if all child Behavior fail.
+func (s *selection) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *selection) ExecuteCtx(ctx context.Context) State {
+	return s.iterate(ctx, false, true, Failure)
+}
+
+// reactiveSequence is a Behavior which is the conjunction of child Behavior,
+// re-evaluated from the first child on every execution.
+type reactiveSequence struct {
+	composite
+}
+
+// ReactiveSequence gets a Behavior with the conjunction of child Behavior.
+// Unlike Sequence, it does not remember which children have succeeded. With no
+// children, it always succeeds.
+func ReactiveSequence(bs ...Behavior) Behavior {
+	return &reactiveSequence{composite{nodes: bs}}
+}
+
+// Execute runs each child Behavior in sequence, starting from the first child
+// every time. It succeeds if all the child Behavior succeed in one pass, but
+// immediately fails if any child fails, aborting and resetting the children
+// after it so that any which were running are torn down.
+func (s *reactiveSequence) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *reactiveSequence) ExecuteCtx(ctx context.Context) State {
+	s.index = 0
+	result := s.iterate(ctx, true, false, Success)
+	if result == Failure {
+		for _, n := range s.nodes[s.index+1:] {
+			interrupt(n)
+		}
+	}
+	return result
+}
+
+// reactiveSelection is a Behavior which is the disjunction of child Behavior,
+// re-evaluated from the first child on every execution.
+type reactiveSelection struct {
+	composite
+}
+
+// ReactiveSelection gets a Behavior with the disjunction of child Behavior.
+// Unlike Selection, it does not remember which children have failed, so higher
+// priority children can preempt lower priority children which are Running.
+// With no children, it always fails.
+func ReactiveSelection(bs ...Behavior) Behavior {
+	return &reactiveSelection{composite{nodes: bs}}
+}
+
+// Execute runs each child Behavior in sequence, starting from the first child
+// every time. It immediately succeeds if any child succeeds, but fails if all
+// child Behavior fail. If a child succeeds or is Running before reaching the
+// child which stopped the previous execution, that child is aborted and reset.
+func (s *reactiveSelection) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *reactiveSelection) ExecuteCtx(ctx context.Context) State {
+	prev := s.index
+	s.index = 0
+	result := s.iterate(ctx, false, true, Failure)
+	switch result {
+	case Running, Success:
+		s.preempt(prev)
+	case Cancelled:
+		// The lower priority child from the previous execution may still be
+		// Running, so remember it to preempt it later.
+		s.index = max(s.index, prev)
+	}
+	return result
+}
+
+// preempt aborts and resets the child at prev if it has lower priority than
+// the current child.
+func (s *reactiveSelection) preempt(prev int) {
+	if prev > s.index && prev < len(s.nodes) {
+		interrupt(s.nodes[prev])
+	}
+}
+
+// interruptingSelection is a reactiveSelection which evaluates each child afresh
+// on every execution.
+type interruptingSelection struct {
+	reactiveSelection
+	last State
+}
+
+// InterruptingSelection gets a Behavior with the disjunction of child Behavior
+// which, like ReactiveSelection, starts from the first child on every execution
+// so that a higher priority child which succeeds or is Running interrupts a
+// lower priority child which was Running, aborting and resetting it. Unlike
+// ReactiveSelection, each child which completed on the previous execution is
+// reset before it is executed again, so that higher priority children are
+// evaluated afresh rather than resuming from where they stopped.
+func InterruptingSelection(bs ...Behavior) Behavior {
+	return &interruptingSelection{reactiveSelection: reactiveSelection{composite{nodes: bs}}}
+}
+
+// Reset forgets the previous result and resets all child Behavior.
+func (s *interruptingSelection) Reset() {
+	s.last = Unknown
+	s.composite.Reset()
+}
+
+// Execute runs each child Behavior in sequence, starting from the first child
+// every time and resetting those which completed on the previous execution. It
+// immediately succeeds if any child succeeds, but fails if all child Behavior
+// fail.
+func (s *interruptingSelection) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *interruptingSelection) ExecuteCtx(ctx context.Context) State {
+	prev, last := s.index, s.last
+	for s.index = 0; s.index < len(s.nodes); s.index++ {
+		n := s.nodes[s.index]
+		if last != Unknown && (s.index < prev || s.index == prev && last != Running && last != Cancelled) {
+			n.Reset()
+		}
+		switch Execute(ctx, n) {
 		case Running:
+			s.preempt(prev)
+			s.last = Running
 			return Running
 		case Success:
-			continue
+			s.preempt(prev)
+			s.last = Success
+			return Success
 		case Failure:
+			continue
+		case Cancelled:
+			if prev > s.index {
+				s.index = prev
+			} else {
+				s.last = Cancelled
+			}
+			return Cancelled
+		default:
+			s.last = Unknown
+			return Unknown
+		}
+	}
+	s.last = Failure
+	return Failure
+}
+
+// switcher is a Behavior which runs one child Behavior chosen by a selector.
+type switcher struct {
+	composite
+	selector func() int
+	chosen   bool
+}
+
+// Switch gets a Behavior which runs the child Behavior at the index returned
+// by selector. The selector is called once per activation.
+func Switch(selector func() int, bs ...Behavior) Behavior {
+	return &switcher{composite: composite{nodes: bs}, selector: selector}
+}
+
+// Reset clears the chosen child and resets all child Behavior.
+func (s *switcher) Reset() {
+	s.chosen = false
+	s.composite.Reset()
+}
+
+// Execute chooses a child if this is a new activation, and then runs the chosen
+// child and returns the result. It fails if the chosen index is out of range.
+func (s *switcher) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *switcher) ExecuteCtx(ctx context.Context) State {
+	if !s.chosen {
+		s.index = s.selector()
+		s.chosen = true
+	}
+	if s.index < 0 || s.index >= len(s.nodes) {
+		return Failure
+	}
+	return Execute(ctx, s.nodes[s.index])
+}
+
+// atLeast is a Behavior which requires some of its child Behavior to succeed.
+type atLeast struct {
+	composite
+	n         int
+	successes int
+}
+
+// AtLeast gets a Behavior which runs child Behavior in sequence, succeeding as
+// soon as n of them succeed, and failing as soon as too few children remain
+// for n to succeed. With n of 0 it always succeeds.
+func AtLeast(n int, bs ...Behavior) Behavior {
+	return &atLeast{composite: composite{nodes: bs}, n: n}
+}
+
+// Reset zeroes the success count and resets all child Behavior.
+func (a *atLeast) Reset() {
+	a.successes = 0
+	a.composite.Reset()
+}
+
+// Execute runs each child Behavior in sequence until n of them have succeeded
+// or it is no longer possible for n of them to succeed.
+func (a *atLeast) Execute() State {
+	return Execute(context.Background(), a)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (a *atLeast) ExecuteCtx(ctx context.Context) State {
+	for ; a.successes < a.n; a.index++ {
+		if a.successes+len(a.nodes)-a.index < a.n {
 			return Failure
+		}
+		switch Execute(ctx, a.nodes[a.index]) {
+		case Running:
+			return Running
+		case Success:
+			a.successes++
+		case Failure:
+			continue
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
@@ -108,132 +510,281 @@ func (s *sequence) Execute() State {
 	return Success
 }
 
-// selection is a Behavior which is the disjunction of child Behavior.
-type selection struct {
+// sequenceAll is a Behavior which runs every child Behavior, even after one
+// fails.
+type sequenceAll struct {
 	composite
+	failed bool
 }
 
-// Selection gets a Behavior with the disjunction of child Beheavior.
-func Selection(bs ...Behavior) Behavior {
-	return &selection{composite{nodes: bs}}
+// SequenceAll gets a Behavior which runs each child Behavior in sequence to
+// completion, and succeeds only if all of them succeed. Unlike Sequence, which
+// fails as soon as any child fails, a failing child does not stop the rest from
+// running, which suits cleanup steps which must all be attempted. Like
+// Sequence, it remembers which children have completed until Reset.
+func SequenceAll(bs ...Behavior) Behavior {
+	return &sequenceAll{composite: composite{nodes: bs}}
 }
 
-// Execute runs each child Behavior in sequence. It immediately succeeds if any
-// the child Behavior suceceed, but fails if all child Behavior fail.
-func (s *selection) Execute() State {
+// Reset clears any failure and resets all child Behavior.
+func (s *sequenceAll) Reset() {
+	s.failed = false
+	s.composite.Reset()
+}
+
+// Execute runs each child Behavior in sequence. Once all the children have
+// completed, it succeeds if all of them succeeded, and fails otherwise.
+func (s *sequenceAll) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *sequenceAll) ExecuteCtx(ctx context.Context) State {
 	for ; s.index < len(s.nodes); s.index++ {
-		switch s.nodes[s.index].Execute() {
+		switch Execute(ctx, s.nodes[s.index]) {
 		case Running:
 			return Running
 		case Success:
-			return Success
-		case Failure:
 			continue
+		case Failure:
+			s.failed = true
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return Failure
+	if s.failed {
+		return Failure
+	}
+	return Success
 }
 
 // pcomposite is the base of a Behavior that runs multiple parallel Behavior.
 type pcomposite struct {
 	nodes    []Behavior
 	complete map[int]bool
+	state    State
 }
 
 // Reset resets all child Behavior.
 func (c *pcomposite) Reset() {
 	c.complete = make(map[int]bool)
+	c.state = Unknown
 	for _, n := range c.nodes {
 		n.Reset()
 	}
 }
 
-// psequence is a Behavior which is the conjunction of parallel child Behavior.
-type psequence struct {
+// Policy describes how many parallel child Behavior must reach a State.
+type Policy int
+
+// Policy constants to be used by Parallel.
+const (
+	RequireOne Policy = iota
+	RequireAll
+)
+
+// parallel is a Behavior which runs child Behavior in parallel, completing
+// according to a success and a failure Policy.
+type parallel struct {
 	pcomposite
+	success, failure    Policy
+	successes, failures int
+	resetOnFail, race   bool
+	batch, cursor       int
+	rng                 *rand.Rand
+}
+
+// Parallel gets a Behavior which runs child Behavior in parallel. It succeeds
+// once the success Policy is met, or fails once the failure Policy is met.
+// With no children, RequireAll is trivially met and RequireOne never is, so it
+// succeeds if the success Policy is RequireAll and fails otherwise.
+func Parallel(success, failure Policy, bs ...Behavior) Behavior {
+	return &parallel{
+		pcomposite: pcomposite{nodes: bs, complete: make(map[int]bool)},
+		success:    success,
+		failure:    failure,
+	}
 }
 
 // PSequence gets a Behavior with the conjunction of parallel child Beheavior.
+// It is equivalent to Parallel(RequireAll, RequireOne, bs...). With no
+// children, it always succeeds, like Sequence.
 func PSequence(bs ...Behavior) Behavior {
-	return &psequence{pcomposite{nodes: bs, complete: make(map[int]bool)}}
+	return Parallel(RequireAll, RequireOne, bs...)
 }
 
-// Execute runs each child behavior in parallel. It succceeds if all the child
-// Behavior succeed, but fails if any child fails.
-func (s *psequence) Execute() State {
-	running := false
-	for i, n := range s.nodes {
-		if s.complete[i] {
-			continue
-		}
-		switch n.Execute() {
-		case Success:
-			s.complete[i] = true
-		case Running:
-			running = true
-		case Failure:
-			return Failure
-		default:
-			return Unknown
-		}
-	}
-	if running {
-		return Running
+// PSelection gets a Behavior with the disjunction of parallel child Beheavior.
+// It is equivalent to Parallel(RequireOne, RequireAll, bs...). With no
+// children, it always fails, like Selection.
+func PSelection(bs ...Behavior) Behavior {
+	return Parallel(RequireOne, RequireAll, bs...)
+}
+
+// PSequenceResetOnFail is like PSequence, but the moment any child fails, every
+// child is reset, and Running children are aborted first, so that they are torn
+// down immediately rather than on the next Reset.
+func PSequenceResetOnFail(bs ...Behavior) Behavior {
+	p := Parallel(RequireAll, RequireOne, bs...).(*parallel)
+	p.resetOnFail = true
+	return p
+}
+
+// Race gets a Behavior which runs child Behavior in parallel and returns the
+// result of whichever child completes first, whether it succeeds or fails.
+// Unlike PSelection, which waits for a success, the first failure ends a Race.
+// The other children are then aborted if Running, and reset. With no children,
+// it always fails.
+func Race(bs ...Behavior) Behavior {
+	p := Parallel(RequireOne, RequireOne, bs...).(*parallel)
+	p.race = true
+	return p
+}
+
+// PSequenceBatched is like PSequence, but ticks at most batch incomplete
+// children per execution, continuing round-robin from where the previous
+// execution stopped, so that the cost of a large set of children is spread
+// over several ticks. Every incomplete child is still ticked in turn, and the
+// result is the same as PSequence, but completion may take more executions,
+// since a child is only seen to succeed or fail on its turn. A batch of less
+// than 1 ticks every child, like PSequence.
+func PSequenceBatched(batch int, bs ...Behavior) Behavior {
+	p := Parallel(RequireAll, RequireOne, bs...).(*parallel)
+	p.batch = batch
+	return p
+}
+
+// Reset zeroes the success and failure counts and resets all child Behavior.
+func (p *parallel) Reset() {
+	p.successes = 0
+	p.failures = 0
+	p.cursor = 0
+	p.pcomposite.Reset()
+}
+
+// met reports whether count children reaching a State satisfies the Policy.
+func (p *parallel) met(policy Policy, count int) bool {
+	if policy == RequireOne {
+		return count >= 1
 	}
-	return Success
+	return count >= len(p.nodes)
 }
 
-// selection is a Behavior which is the disjunction of parallel child Behavior.
-type pselection struct {
-	pcomposite
+// status gets the State implied by the children which have completed so far.
+// If every child has completed without meeting either Policy, it fails.
+func (p *parallel) status() State {
+	switch {
+	case p.met(p.success, p.successes):
+		return Success
+	case p.met(p.failure, p.failures):
+		return Failure
+	case p.successes+p.failures == len(p.nodes):
+		return Failure
+	default:
+		return Running
+	}
 }
 
-// PSelection gets a Behavior with the disjunction of parallel child Beheavior.
-func PSelection(bs ...Behavior) Behavior {
-	return &pselection{pcomposite{nodes: bs, complete: make(map[int]bool)}}
+// Execute runs each incomplete child Behavior in parallel. It succeeds as soon
+// as the success Policy is met, and fails as soon as the failure Policy is met.
+func (p *parallel) Execute() State {
+	return Execute(context.Background(), p)
 }
 
-// Execute runs each child behavior in parallel. It succceeds if any the child
-// Behavior succeed, but fails if all child Behavior fail.
-func (s *pselection) Execute() State {
-	running := false
-	for i, n := range s.nodes {
-		if s.complete[i] {
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (p *parallel) ExecuteCtx(ctx context.Context) State {
+	if s := p.status(); s != Running {
+		return s
+	}
+	perm := p.perm()
+	ticked := 0
+	for i := range p.nodes {
+		if perm != nil {
+			i = perm[i]
+		} else if p.batch > 0 {
+			i = (p.cursor + i) % len(p.nodes)
+		}
+		if p.complete[i] {
 			continue
 		}
-		switch n.Execute() {
+		if p.batch > 0 && ticked == p.batch {
+			p.cursor = i
+			break
+		}
+		ticked++
+		switch Execute(ctx, p.nodes[i]) {
+		case Success:
+			p.complete[i] = true
+			p.successes++
 		case Failure:
-			s.complete[i] = true
+			p.complete[i] = true
+			p.failures++
 		case Running:
-			running = true
-		case Success:
-			return Success
+			continue
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
+		if s := p.status(); s != Running {
+			if s == Failure && p.resetOnFail {
+				for _, n := range p.nodes {
+					interrupt(n)
+				}
+			}
+			if p.race {
+				for j, n := range p.nodes {
+					if !p.complete[j] {
+						interrupt(n)
+					}
+				}
+			}
+			return s
+		}
 	}
-	if running {
-		return Running
-	}
-	return Failure
+	return Running
+}
+
+// wrapper is the base of a Behavior which wraps another Behavior.
+type wrapper struct {
+	node  Behavior
+	state State
+}
+
+// Reset resets the underlying Behavior.
+func (w *wrapper) Reset() {
+	w.state = Unknown
+	w.node.Reset()
 }
 
-// decorator is a Behavior which transforms the output of another Behavior.
+// decorator is a Behavior which transforms the output of another Behavior. If
+// the transform turns Success or Failure into Running, the underlying Behavior
+// is reset so that it runs again.
 type decorator struct {
-	node      Behavior
+	wrapper
+	name      string
 	transform func(State) State
 }
 
-// Reset resets the underlying Behavior.
-func (d *decorator) Reset() {
-	d.node.Reset()
+// Name gets the name of the transformation.
+func (d *decorator) Name() string {
+	return d.name
 }
 
 // Execute runs the underlying Behavior, but returns the transformed State.
 func (d *decorator) Execute() State {
-	return d.transform(d.node.Execute())
+	return Execute(context.Background(), d)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (d *decorator) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, d.node)
+	t := d.transform(s)
+	if t == Running && (s == Success || s == Failure) {
+		d.node.Reset()
+	}
+	return t
 }
 
 // Invert wraps a Behavior to invert Success and Failure.
@@ -246,11 +797,22 @@ func Invert(b Behavior) Behavior {
 			return Failure
 		case Failure:
 			return Success
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, invert}
+	return &decorator{wrapper{node: b}, "Invert", invert}
+}
+
+// Not negates a Behavior. A Conditional is negated directly, giving another
+// Conditional, while any other Behavior is wrapped with Invert.
+func Not(b Behavior) Behavior {
+	if c, ok := b.(Conditional); ok {
+		return Conditional(func() bool { return !c() })
+	}
+	return Invert(b)
 }
 
 // Repeat wraps a Behavior to make it run indefinitely.
@@ -258,18 +820,36 @@ func Repeat(b Behavior) Behavior {
 	repeat := func(s State) State {
 		switch s {
 		case Success, Failure:
-			b.Reset()
 			return Running
 		case Running:
 			return Running
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, repeat}
+	return &decorator{wrapper{node: b}, "Repeat", repeat}
 }
 
-// ForceSuccess wraps a Behavior so Failure instead results in Success.
+// AlwaysRunning wraps a Behavior so it always results in Running, regardless of
+// the result of the underlying Behavior, except that Cancelled is passed
+// through. Like Repeat, the underlying Behavior is reset and run again each time
+// it completes.
+func AlwaysRunning(b Behavior) Behavior {
+	running := func(s State) State {
+		if s == Cancelled {
+			return Cancelled
+		}
+		return Running
+	}
+	return &decorator{wrapper{node: b}, "AlwaysRunning", running}
+}
+
+// ForceSuccess wraps a Behavior so Failure instead results in Success. Running
+// is passed through, so the underlying Behavior is still run to completion
+// before succeeding. The underlying Behavior is not reset on completion, so a
+// new activation requires a Reset as usual.
 func ForceSuccess(b Behavior) Behavior {
 	force := func(s State) State {
 		switch s {
@@ -277,11 +857,18 @@ func ForceSuccess(b Behavior) Behavior {
 			return Success
 		case Running:
 			return Running
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, force}
+	return &decorator{wrapper{node: b}, "ForceSuccess", force}
+}
+
+// AlwaysSucceed is an alias of ForceSuccess.
+func AlwaysSucceed(b Behavior) Behavior {
+	return ForceSuccess(b)
 }
 
 // ForceFailure  wraps a Behavior so Success instead results in Failure.
@@ -292,11 +879,13 @@ func ForceFailure(b Behavior) Behavior {
 			return Failure
 		case Running:
 			return Running
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, force}
+	return &decorator{wrapper{node: b}, "ForceFailure", force}
 }
 
 // Until wraps a Behavior so it runs repeatedly until Success.
@@ -306,15 +895,16 @@ func Until(b Behavior) Behavior {
 		case Success:
 			return Success
 		case Failure:
-			b.Reset()
 			return Running
 		case Running:
 			return Running
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, until}
+	return &decorator{wrapper{node: b}, "Until", until}
 }
 
 // While wraps a Behavior so it runs repeatedly until Failure.
@@ -324,13 +914,864 @@ func While(b Behavior) Behavior {
 		case Failure:
 			return Failure
 		case Success:
-			b.Reset()
 			return Running
 		case Running:
 			return Running
+		case Cancelled:
+			return Cancelled
+		default:
+			return Unknown
+		}
+	}
+	return &decorator{wrapper{node: b}, "While", while}
+}
+
+// repeatN is a Behavior which runs another Behavior a fixed number of times.
+type repeatN struct {
+	wrapper
+	n, count int
+}
+
+// RepeatN wraps a Behavior so it runs n times before succeeding.
+func RepeatN(b Behavior, n int) Behavior {
+	return &repeatN{wrapper: wrapper{node: b}, n: n}
+}
+
+// Reset zeroes the count and resets the underlying Behavior.
+func (r *repeatN) Reset() {
+	r.count = 0
+	r.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, resetting it each time it completes.
+// It succeeds once the underlying Behavior has completed n times.
+func (r *repeatN) Execute() State {
+	return Execute(context.Background(), r)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (r *repeatN) ExecuteCtx(ctx context.Context) State {
+	if r.count >= r.n {
+		return Success
+	}
+	switch Execute(ctx, r.node) {
+	case Success, Failure:
+		r.count++
+		if r.count >= r.n {
+			return Success
+		}
+		r.node.Reset()
+		return Running
+	case Running:
+		return Running
+	case Cancelled:
+		return Cancelled
+	default:
+		return Unknown
+	}
+}
+
+// retryN is a Behavior which retries another Behavior a fixed number of times.
+type retryN struct {
+	wrapper
+	n, count int
+}
+
+// RetryN wraps a Behavior so it is retried until Success, but fails after n
+// failed attempts.
+func RetryN(b Behavior, n int) Behavior {
+	return &retryN{wrapper: wrapper{node: b}, n: n}
+}
+
+// Reset zeroes the attempt count and resets the underlying Behavior.
+func (r *retryN) Reset() {
+	r.count = 0
+	r.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, resetting it each time it fails. It
+// fails once the underlying Behavior has failed n times.
+func (r *retryN) Execute() State {
+	return Execute(context.Background(), r)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (r *retryN) ExecuteCtx(ctx context.Context) State {
+	switch Execute(ctx, r.node) {
+	case Success:
+		return Success
+	case Failure:
+		r.count++
+		if r.count >= r.n {
+			return Failure
+		}
+		r.node.Reset()
+		return Running
+	case Running:
+		return Running
+	case Cancelled:
+		return Cancelled
+	default:
+		return Unknown
+	}
+}
+
+// limit is a Behavior which caps the executions of another Behavior.
+type limit struct {
+	wrapper
+	max, count int
+}
+
+// Limit wraps a Behavior so it executes at most max times, after which it
+// fails without executing the underlying Behavior.
+func Limit(b Behavior, max int) Behavior {
+	return &limit{wrapper: wrapper{node: b}, max: max}
+}
+
+// Reset zeroes the execution count and resets the underlying Behavior.
+func (l *limit) Reset() {
+	l.count = 0
+	l.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior if it has been executed fewer than max
+// times, and fails otherwise. Running counts towards the limit.
+func (l *limit) Execute() State {
+	return Execute(context.Background(), l)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (l *limit) ExecuteCtx(ctx context.Context) State {
+	if l.count >= l.max {
+		return Failure
+	}
+	l.count++
+	return Execute(ctx, l.node)
+}
+
+// maxSuccess is a Behavior which limits how many times another Behavior may
+// succeed.
+type maxSuccess struct {
+	wrapper
+	n, count int
+}
+
+// MaxSuccess wraps a Behavior so that only its first n successes pass through,
+// after which each further Success becomes Failure until Reset. The underlying
+// Behavior is reset after it succeeds or fails, so that it runs again on the
+// next execution. It is the success-side analogue of CircuitBreaker.
+func MaxSuccess(b Behavior, n int) Behavior {
+	return &maxSuccess{wrapper: wrapper{node: b}, n: n}
+}
+
+// Reset zeroes the success count and resets the underlying Behavior.
+func (m *maxSuccess) Reset() {
+	m.count = 0
+	m.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior and returns the result, except that
+// Success becomes Failure once n successes have passed through.
+func (m *maxSuccess) Execute() State {
+	return Execute(context.Background(), m)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (m *maxSuccess) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, m.node)
+	switch s {
+	case Success:
+		m.node.Reset()
+		if m.count >= m.n {
+			return Failure
+		}
+		m.count++
+	case Failure:
+		m.node.Reset()
+	}
+	return s
+}
+
+// delay is a Behavior which waits before running another Behavior.
+type delay struct {
+	wrapper
+	ticks, count int
+}
+
+// Delay wraps a Behavior so it is Running for the given number of ticks before
+// executing the underlying Behavior.
+func Delay(b Behavior, ticks int) Behavior {
+	return &delay{wrapper: wrapper{node: b}, ticks: ticks}
+}
+
+// Reset restarts the delay and resets the underlying Behavior.
+func (d *delay) Reset() {
+	d.count = 0
+	d.wrapper.Reset()
+}
+
+// Execute returns Running until the delay has elapsed, and thereafter runs the
+// underlying Behavior and returns the result.
+func (d *delay) Execute() State {
+	return Execute(context.Background(), d)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (d *delay) ExecuteCtx(ctx context.Context) State {
+	if d.count < d.ticks {
+		d.count++
+		return Running
+	}
+	return Execute(ctx, d.node)
+}
+
+// cooldown is a Behavior which prevents another Behavior from running again
+// too soon after it succeeds.
+type cooldown struct {
+	wrapper
+	ticks, remaining int
+}
+
+// Cooldown wraps a Behavior so that after it succeeds, it fails without being
+// executed for the given number of ticks.
+func Cooldown(b Behavior, ticks int) Behavior {
+	return &cooldown{wrapper: wrapper{node: b}, ticks: ticks}
+}
+
+// Reset clears the cooldown and resets the underlying Behavior.
+func (c *cooldown) Reset() {
+	c.remaining = 0
+	c.wrapper.Reset()
+}
+
+// Execute fails while cooling down, and otherwise runs the underlying Behavior
+// and returns the result. On Success, the underlying Behavior is reset so it
+// can run again once the cooldown has elapsed.
+func (c *cooldown) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (c *cooldown) ExecuteCtx(ctx context.Context) State {
+	if c.remaining > 0 {
+		c.remaining--
+		return Failure
+	}
+	s := Execute(ctx, c.node)
+	if s == Success {
+		c.remaining = c.ticks
+		c.node.Reset()
+	}
+	return s
+}
+
+// debounce is a Behavior which suppresses another Behavior for a while after
+// it succeeds.
+type debounce struct {
+	wrapper
+	ticks, remaining int
+}
+
+// Debounce wraps a Behavior so that after it succeeds, it succeeds again without
+// being executed for the given number of ticks. Unlike Cooldown, which fails
+// while cooling down, Debounce repeats the previous Success, and unlike Once or
+// EdgeTrigger, the underlying Behavior runs again once the window has elapsed.
+func Debounce(b Behavior, ticks int) Behavior {
+	return &debounce{wrapper: wrapper{node: b}, ticks: ticks}
+}
+
+// Reset clears the debounce window and resets the underlying Behavior.
+func (d *debounce) Reset() {
+	d.remaining = 0
+	d.wrapper.Reset()
+}
+
+// Execute succeeds during the debounce window, and otherwise runs the
+// underlying Behavior and returns the result. On Success, the underlying
+// Behavior is reset so it can run again once the window has elapsed.
+func (d *debounce) Execute() State {
+	return Execute(context.Background(), d)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (d *debounce) ExecuteCtx(ctx context.Context) State {
+	if d.remaining > 0 {
+		d.remaining--
+		return Success
+	}
+	s := Execute(ctx, d.node)
+	if s == Success {
+		d.remaining = d.ticks
+		d.node.Reset()
+	}
+	return s
+}
+
+// everyN is a Behavior which runs another Behavior at a reduced frequency.
+type everyN struct {
+	wrapper
+	n, count int
+	last     State
+}
+
+// EveryN wraps a Behavior so that it is only executed on the first of every n
+// executions, starting with the first. On the skipped executions, the result of
+// the last execution of the underlying Behavior is returned again. Use
+// AutoReset on the underlying Behavior if it should start over each time.
+func EveryN(b Behavior, n int) Behavior {
+	return &everyN{wrapper: wrapper{node: b}, n: n}
+}
+
+// Reset zeroes the execution count, clears the last result, and resets the
+// underlying Behavior.
+func (e *everyN) Reset() {
+	e.count = 0
+	e.last = Unknown
+	e.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior if it is due and returns the result, or
+// returns the last result otherwise.
+func (e *everyN) Execute() State {
+	return Execute(context.Background(), e)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (e *everyN) ExecuteCtx(ctx context.Context) State {
+	if e.count%max(e.n, 1) == 0 {
+		s := Execute(ctx, e.node)
+		if s == Cancelled {
+			return Cancelled
+		}
+		e.last = s
+	}
+	e.count++
+	return e.last
+}
+
+// parallelN is a Behavior which requires a number of parallel child Behavior
+// to succeed.
+type parallelN struct {
+	pcomposite
+	threshold, successes, failures int
+}
+
+// ParallelN gets a Behavior which requires at least threshold of the parallel
+// child Behavior to succeed.
+func ParallelN(threshold int, bs ...Behavior) Behavior {
+	return &parallelN{
+		pcomposite: pcomposite{nodes: bs, complete: make(map[int]bool)},
+		threshold:  threshold,
+	}
+}
+
+// Reset zeroes the success and failure counts and resets all child Behavior.
+func (p *parallelN) Reset() {
+	p.successes = 0
+	p.failures = 0
+	p.pcomposite.Reset()
+}
+
+// Execute runs each incomplete child Behavior in parallel. It succeeds once at
+// least threshold children have succeeded, but fails once so many children
+// have failed that threshold successes is impossible.
+func (p *parallelN) Execute() State {
+	return Execute(context.Background(), p)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (p *parallelN) ExecuteCtx(ctx context.Context) State {
+	if p.successes >= p.threshold {
+		return Success
+	}
+	if len(p.nodes)-p.failures < p.threshold {
+		return Failure
+	}
+	for i, n := range p.nodes {
+		if p.complete[i] {
+			continue
+		}
+		switch Execute(ctx, n) {
+		case Success:
+			p.complete[i] = true
+			p.successes++
+			if p.successes >= p.threshold {
+				return Success
+			}
+		case Failure:
+			p.complete[i] = true
+			p.failures++
+			if len(p.nodes)-p.failures < p.threshold {
+				return Failure
+			}
+		case Running:
+			continue
+		case Cancelled:
+			return Cancelled
 		default:
 			return Unknown
 		}
 	}
-	return &decorator{b, while}
+	return Running
+}
+
+// waitTicks is a Behavior which waits for a number of ticks.
+type waitTicks struct {
+	n, count int
+}
+
+// WaitTicks gets a Behavior which is Running for n executions, and then
+// succeeds.
+func WaitTicks(n int) Behavior {
+	return &waitTicks{n: n}
+}
+
+// Reset restarts the wait.
+func (w *waitTicks) Reset() {
+	w.count = 0
+}
+
+// Execute returns Running for the first n executions, and Success after.
+func (w *waitTicks) Execute() State {
+	if w.count < w.n {
+		w.count++
+		return Running
+	}
+	return Success
+}
+
+// autoReset is a Behavior which resets another Behavior after it completes.
+type autoReset struct {
+	wrapper
+	done bool
+}
+
+// AutoReset wraps a Behavior so that after it succeeds or fails, it is reset
+// before it is next executed, so each activation starts from scratch.
+func AutoReset(b Behavior) Behavior {
+	return &autoReset{wrapper: wrapper{node: b}}
+}
+
+// Reset resets the underlying Behavior.
+func (a *autoReset) Reset() {
+	a.done = false
+	a.wrapper.Reset()
+}
+
+// Execute resets the underlying Behavior if it completed on the previous
+// execution, and then runs it and returns the result.
+func (a *autoReset) Execute() State {
+	return Execute(context.Background(), a)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (a *autoReset) ExecuteCtx(ctx context.Context) State {
+	if a.done {
+		a.node.Reset()
+	}
+	s := Execute(ctx, a.node)
+	a.done = s == Success || s == Failure
+	return s
+}
+
+// memoized is a Behavior which caches the result of a Conditional.
+type memoized struct {
+	cond   Conditional
+	result State
+}
+
+// Memoize gets a Behavior which evaluates the Conditional only on its first
+// execution of each activation, and replays the cached result until Reset.
+// Changes in the condition during an activation are deliberately ignored.
+func Memoize(c Conditional) Behavior {
+	return &memoized{cond: c}
+}
+
+// Reset clears the cached result.
+func (m *memoized) Reset() {
+	m.result = Unknown
+}
+
+// Execute returns the cached result, evaluating the Conditional if there is
+// none.
+func (m *memoized) Execute() State {
+	if m.result == Unknown {
+		m.result = m.cond.Execute()
+	}
+	return m.result
+}
+
+// once is a Behavior which latches the first result of another Behavior.
+type once struct {
+	wrapper
+	result State
+}
+
+// Once wraps a Behavior so that once it succeeds or fails, that result is
+// returned on every later execution without running it again, until Reset.
+// Unlike Repeat, the underlying Behavior is never run again in an activation.
+// Unlike EdgeTrigger, a Success is repeated rather than reported only once.
+func Once(b Behavior) Behavior {
+	return &once{wrapper: wrapper{node: b}}
+}
+
+// Reset clears the latched result and resets the underlying Behavior.
+func (o *once) Reset() {
+	o.result = Unknown
+	o.wrapper.Reset()
+}
+
+// Execute returns the latched result if there is one, and otherwise runs the
+// underlying Behavior and returns the result.
+func (o *once) Execute() State {
+	return Execute(context.Background(), o)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (o *once) ExecuteCtx(ctx context.Context) State {
+	if o.result != Unknown {
+		return o.result
+	}
+	s := Execute(ctx, o.node)
+	if s == Success || s == Failure {
+		o.result = s
+	}
+	return s
+}
+
+// edgeTrigger is a Behavior which passes through only the first Success of
+// another Behavior.
+type edgeTrigger struct {
+	wrapper
+	fired bool
+}
+
+// EdgeTrigger wraps a Behavior so that its first Success is passed through, and
+// every later execution fails without running it, until Reset. This models an
+// event which should fire once per activation. Unlike Once, which repeats the
+// first result forever, EdgeTrigger reports the Success only once; unlike
+// Debounce, it does not run the underlying Behavior again after any window.
+func EdgeTrigger(b Behavior) Behavior {
+	return &edgeTrigger{wrapper: wrapper{node: b}}
+}
+
+// Reset rearms the trigger and resets the underlying Behavior.
+func (e *edgeTrigger) Reset() {
+	e.fired = false
+	e.wrapper.Reset()
+}
+
+// Execute fails if the trigger has fired, and otherwise runs the underlying
+// Behavior and returns the result.
+func (e *edgeTrigger) Execute() State {
+	return Execute(context.Background(), e)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (e *edgeTrigger) ExecuteCtx(ctx context.Context) State {
+	if e.fired {
+		return Failure
+	}
+	s := Execute(ctx, e.node)
+	e.fired = s == Success
+	return s
+}
+
+// ref is a Behavior which obtains its underlying Behavior lazily.
+type ref struct {
+	wrapper
+	provider func() Behavior
+}
+
+// Ref gets a Behavior which calls provider on its first execution to obtain the
+// underlying Behavior, and then runs that same Behavior for every execution
+// after, including after Reset. Since the provider is not called until the Ref
+// is executed, a subtree may be defined recursively, so long as provider builds
+// a new subtree each time; a Ref must not obtain one of its own ancestors,
+// since resetting or walking the tree would then never end. Until the first
+// execution, the Ref has no children.
+func Ref(provider func() Behavior) Behavior {
+	return &ref{provider: provider}
+}
+
+// Reset resets the underlying Behavior, if it has been obtained.
+func (r *ref) Reset() {
+	if r.node != nil {
+		r.wrapper.Reset()
+	}
+}
+
+// Execute obtains the underlying Behavior if needed, and then runs it and
+// returns the result.
+func (r *ref) Execute() State {
+	return Execute(context.Background(), r)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (r *ref) ExecuteCtx(ctx context.Context) State {
+	if r.node == nil {
+		r.node = r.provider()
+	}
+	return Execute(ctx, r.node)
+}
+
+// lazy is a ref which discards its underlying Behavior on Reset.
+type lazy struct {
+	ref
+}
+
+// Lazy gets a Behavior which calls provider on its first execution to construct
+// the underlying Behavior, like Ref, but which discards the underlying Behavior
+// on Reset, so that it is constructed again the next time it is executed. This
+// saves memory for heavy subtrees which are rarely reached, such as the later
+// children of a Selection, at the cost of constructing the subtree afresh on
+// every activation which reaches it. Until it is executed, the Lazy has no
+// children.
+func Lazy(provider func() Behavior) Behavior {
+	return &lazy{ref{provider: provider}}
+}
+
+// Reset discards the underlying Behavior.
+func (l *lazy) Reset() {
+	l.state = Unknown
+	l.node = nil
+}
+
+// guard is a Behavior which runs another Behavior only while a condition holds.
+type guard struct {
+	wrapper
+	cond Conditional
+}
+
+// Guard gets a Behavior which checks cond on every execution. If cond holds,
+// body is run and its result returned. Otherwise, body is aborted if Running,
+// then reset, and the Guard fails, so that a condition may interrupt the body.
+func Guard(cond Conditional, body Behavior) Behavior {
+	return &guard{wrapper{node: body}, cond}
+}
+
+// Execute checks the condition, and then either runs the body and returns the
+// result, or resets the body and returns Failure.
+func (g *guard) Execute() State {
+	return Execute(context.Background(), g)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the body.
+func (g *guard) ExecuteCtx(ctx context.Context) State {
+	if !g.cond() {
+		interrupt(g.node)
+		return Failure
+	}
+	return Execute(ctx, g.node)
+}
+
+// gate is a Behavior which runs another Behavior only while it is enabled.
+type gate struct {
+	wrapper
+	enabled *bool
+	running bool
+}
+
+// Gate gets a Behavior which reads *enabled on every execution, so that a
+// subtree can be switched on and off at runtime by changing the bool it points
+// to, without rebuilding the tree. While *enabled is true, b is run and its
+// result returned. While it is false, b is not run and the Gate fails. If b
+// was Running when the Gate was disabled, b is aborted and reset, so that it
+// starts over once the Gate is enabled again.
+func Gate(b Behavior, enabled *bool) Behavior {
+	return &gate{wrapper: wrapper{node: b}, enabled: enabled}
+}
+
+// Execute runs the underlying Behavior and returns the result if the Gate is
+// enabled, and returns Failure otherwise.
+func (g *gate) Execute() State {
+	return Execute(context.Background(), g)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (g *gate) ExecuteCtx(ctx context.Context) State {
+	if !*g.enabled {
+		if g.running {
+			interrupt(g.node)
+			g.running = false
+		}
+		return Failure
+	}
+	s := Execute(ctx, g.node)
+	g.running = s == Running || s == Cancelled
+	return s
+}
+
+// Reset resets the underlying Behavior.
+func (g *gate) Reset() {
+	g.wrapper.Reset()
+	g.running = false
+}
+
+// checkpoint is a Behavior which marks a rollback point in a
+// CheckpointSequence.
+type checkpoint struct {
+	wrapper
+}
+
+// Checkpoint marks b as a checkpoint for an enclosing CheckpointSequence. The
+// Behavior is otherwise unchanged, and outside a CheckpointSequence the mark
+// has no effect.
+func Checkpoint(b Behavior) Behavior {
+	return &checkpoint{wrapper{node: b}}
+}
+
+// Execute runs the underlying Behavior and returns the result.
+func (c *checkpoint) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx runs the underlying Behavior with ctx and returns the result.
+func (c *checkpoint) ExecuteCtx(ctx context.Context) State {
+	return Execute(ctx, c.node)
+}
+
+// checkpointSequence is a Behavior which is the conjunction of child Behavior,
+// but which rolls back to a checkpoint instead of failing.
+type checkpointSequence struct {
+	composite
+}
+
+// CheckpointSequence gets a Behavior which runs child Behavior in sequence like
+// Sequence, except that when a child fails, it rolls back to the last child
+// marked by Checkpoint which comes before the failed child, and retries from
+// that checkpoint on the next execution. Each child from the checkpoint up to
+// the failed child is reset, and Running is returned for the rollback. A child
+// is a checkpoint if it is marked by Checkpoint, even if the mark is wrapped by
+// other decorators, such as Named or those added by Instrument or TraceTree. A
+// checkpoint which itself fails rolls back to the checkpoint before it. If
+// there is no such checkpoint, the CheckpointSequence fails. With no children,
+// it always succeeds.
+func CheckpointSequence(bs ...Behavior) Behavior {
+	return &checkpointSequence{composite{nodes: bs}}
+}
+
+// Execute runs each child Behavior in sequence, rolling back to the last
+// checkpoint if a child fails.
+func (s *checkpointSequence) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *checkpointSequence) ExecuteCtx(ctx context.Context) State {
+	r := s.iterate(ctx, true, false, Success)
+	if r != Failure {
+		return r
+	}
+	for i := s.index - 1; i >= 0; i-- {
+		if _, ok := unwrap[*checkpoint](s.nodes[i]); ok {
+			for _, n := range s.nodes[i : s.index+1] {
+				n.Reset()
+			}
+			s.index = i
+			return Running
+		}
+	}
+	return Failure
+}
+
+// fork is a Behavior which runs a side Behavior alongside a main Behavior.
+type fork struct {
+	pcomposite
+}
+
+// Fork gets a Behavior which, on every execution, first runs side purely for
+// its side effects, such as logging, and then runs main and returns its result.
+// The result of side is ignored, and once side succeeds or fails it is reset so
+// that it runs again on the next execution. Reset resets both main and side.
+func Fork(main, side Behavior) Behavior {
+	return &fork{pcomposite{nodes: []Behavior{main, side}, complete: make(map[int]bool)}}
+}
+
+// Execute runs the side Behavior, and then runs the main Behavior and returns
+// the result.
+func (f *fork) Execute() State {
+	return Execute(context.Background(), f)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (f *fork) ExecuteCtx(ctx context.Context) State {
+	main, side := f.nodes[0], f.nodes[1]
+	if s := Execute(ctx, side); s == Success || s == Failure {
+		side.Reset()
+		f.complete[1] = true
+	} else {
+		delete(f.complete, 1)
+	}
+	s := Execute(ctx, main)
+	if s == Success || s == Failure {
+		f.complete[0] = true
+	} else {
+		delete(f.complete, 0)
+	}
+	return s
+}
+
+// streak is a Behavior which requires a condition to hold for several
+// consecutive executions.
+type streak struct {
+	cond     Conditional
+	n, count int
+}
+
+// Streak gets a Behavior which checks c on every execution, and succeeds once c
+// has held for n consecutive executions, failing until then. Once reached, it
+// keeps succeeding for as long as c holds. A single execution on which c does
+// not hold fails and restarts the streak, as does Reset.
+func Streak(c Conditional, n int) Behavior {
+	return &streak{cond: c, n: n}
+}
+
+// Reset restarts the streak.
+func (s *streak) Reset() {
+	s.count = 0
+}
+
+// Execute checks the condition, and succeeds if it has held for the last n
+// executions, or fails otherwise.
+func (s *streak) Execute() State {
+	if !s.cond() {
+		s.count = 0
+		return Failure
+	}
+	if s.count < s.n {
+		s.count++
+	}
+	if s.count < s.n {
+		return Failure
+	}
+	return Success
+}
+
+// mirror is a Behavior which reports the State of another Behavior.
+type mirror struct {
+	target Behavior
+}
+
+// Mirror gets a Behavior which, instead of executing target, returns the State
+// target last returned, so that a decision made elsewhere in the tree can be
+// reused without running it again. The target must be Stateful, so a leaf
+// should be wrapped with Named. If target has not run since it was last Reset,
+// or is not Stateful, Mirror fails. The target is not a child of the Mirror,
+// so it is not visited by Walk, reset, or copied by Clone through the Mirror.
+func Mirror(target Behavior) Behavior {
+	return &mirror{target}
+}
+
+// Reset is a noop.
+func (m *mirror) Reset() {}
+
+// Execute returns the State the target last returned, or Failure if there is
+// none.
+func (m *mirror) Execute() State {
+	s, ok := m.target.(Stateful)
+	if !ok || s.State() == Unknown {
+		return Failure
+	}
+	return s.State()
 }
@@ -1,9 +1,11 @@
 package bt
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func CheckBehavior(name string, t *testing.T, b Behavior, expected []State) {
@@ -121,6 +123,85 @@ func TestSelection_Unknown(t *testing.T) {
 	CheckBehavior("Selection (Unknown)", t, b, expected)
 }
 
+func TestSelectionOr_Fallback(t *testing.T) {
+	fallback := &testBehavior{base: Recorded(Running, Success)}
+	b := SelectionOr(fallback, Recorded(Running, Failure), Recorded(Failure))
+	expected := []State{Running, Running, Success}
+	CheckBehavior("SelectionOr (Fallback)", t, b, expected)
+	if fallback.calls != 2 {
+		t.Error("SelectionOr ran fallback before children failed", fallback.calls)
+	}
+	b.Reset()
+	if fallback.resets != 1 {
+		t.Error("SelectionOr failed to reset fallback")
+	}
+}
+
+func TestSelectionOr_Success(t *testing.T) {
+	fallback := &testBehavior{base: Recorded(Success)}
+	b := SelectionOr(fallback, Recorded(Failure), Recorded(Running, Success))
+	expected := []State{Running, Success}
+	CheckBehavior("SelectionOr (Success)", t, b, expected)
+	if fallback.calls != 0 {
+		t.Error("SelectionOr ran fallback after a child succeeded")
+	}
+}
+
+func TestAtLeast_Success(t *testing.T) {
+	last := &testBehavior{base: Recorded(Success)}
+	b := AtLeast(2,
+		Recorded(Running, Success),
+		Recorded(Failure),
+		Recorded(Running, Success),
+		last,
+	)
+	expected := []State{Running, Running, Success, Success}
+	CheckBehavior("AtLeast (Success)", t, b, expected)
+	if last.calls != 0 {
+		t.Error("AtLeast executed child after succeeding")
+	}
+}
+
+func TestAtLeast_Failure(t *testing.T) {
+	last := &testBehavior{base: Recorded(Success)}
+	b := AtLeast(2,
+		Recorded(Failure),
+		Recorded(Running, Failure),
+		last,
+	)
+	expected := []State{Running, Failure, Failure}
+	CheckBehavior("AtLeast (Failure)", t, b, expected)
+	if last.calls != 0 {
+		t.Error("AtLeast executed child after becoming impossible")
+	}
+}
+
+func TestSequenceAll_Success(t *testing.T) {
+	b := SequenceAll(
+		Recorded(Running, Success),
+		Recorded(Success),
+		Recorded(Running, Success),
+	)
+	expected := []State{Running, Running, Success, Success}
+	CheckBehavior("SequenceAll (Success)", t, b, expected)
+}
+
+func TestSequenceAll_Failure(t *testing.T) {
+	last := &testBehavior{base: Recorded(Running, Success)}
+	b := SequenceAll(
+		Recorded(Failure),
+		Recorded(Running, Failure),
+		last,
+	)
+	expected := []State{Running, Running, Failure, Failure}
+	CheckBehavior("SequenceAll (Failure)", t, b, expected)
+	if last.calls != 2 {
+		t.Error("SequenceAll failed to run child after failure", last.calls)
+	}
+	b.Reset()
+	CheckBehavior("SequenceAll (Reset)", t, b, []State{Running})
+}
+
 func TestPSequence_Success(t *testing.T) {
 	child := &testBehavior{base: Recorded(Running, Running, Success)}
 	b := PSequence(
@@ -163,6 +244,84 @@ func TestPSequence_Unknown(t *testing.T) {
 	}
 }
 
+func TestPSequenceResetOnFail(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Running)},
+		{base: Recorded(Running, Failure)},
+		{base: Recorded(Success)},
+	}
+	b := PSequenceResetOnFail(children[0], children[1], children[2])
+	expected := []State{Running, Failure}
+	CheckBehavior("PSequenceResetOnFail", t, b, expected)
+	for i, child := range children {
+		if child.resets != 1 {
+			t.Errorf("PSequenceResetOnFail reset child %d %d times", i, child.resets)
+		}
+	}
+	CheckBehavior("PSequenceResetOnFail (Complete)", t, b, []State{Failure})
+	if children[0].calls != 2 {
+		t.Error("PSequenceResetOnFail executed children after failing")
+	}
+}
+
+func TestRace_Failure(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Running, Running, Success)},
+		{base: Recorded(Running, Failure)},
+		{base: Recorded(Running, Running)},
+	}
+	b := Race(children[0], children[1], children[2])
+	CheckBehavior("Race (Failure)", t, b, []State{Running, Failure})
+	for i, expected := range []int{1, 0, 1} {
+		if children[i].resets != expected {
+			t.Errorf("Race (Failure) reset child %d %d times", i, children[i].resets)
+		}
+	}
+	if children[2].calls != 1 {
+		t.Error("Race (Failure) executed children after the first completed")
+	}
+}
+
+func TestRace_Success(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Running, Success)},
+		{base: Recorded(Running, Running, Failure)},
+	}
+	b := Race(children[0], children[1])
+	CheckBehavior("Race (Success)", t, b, []State{Running, Success})
+	if children[0].resets != 0 || children[1].resets != 1 {
+		t.Error("Race (Success) failed to reset only the unfinished child")
+	}
+	if children[1].calls != 1 {
+		t.Error("Race (Success) executed children after the first completed")
+	}
+	CheckBehavior("Race (Complete)", t, b, []State{Success})
+}
+
+func TestPSequenceBatched(t *testing.T) {
+	var children []*testBehavior
+	var nodes []Behavior
+	for i := 0; i < 5; i++ {
+		child := &testBehavior{base: Recorded(Running, Success)}
+		children = append(children, child)
+		nodes = append(nodes, child)
+	}
+	b := PSequenceBatched(2, nodes...)
+	expected := []State{Running, Running, Running, Running, Success}
+	CheckBehavior("PSequenceBatched", t, b, expected)
+	for i, child := range children {
+		if child.calls != 2 {
+			t.Errorf("PSequenceBatched ticked child %d %d times", i, child.calls)
+		}
+	}
+}
+
+func TestPSequenceBatched_Failure(t *testing.T) {
+	b := PSequenceBatched(1, Recorded(Running), Recorded(Failure), Recorded(Running))
+	expected := []State{Running, Failure}
+	CheckBehavior("PSequenceBatched (Failure)", t, b, expected)
+}
+
 func TestPSelection_Success(t *testing.T) {
 	child := &testBehavior{base: Recorded(Running, Running, Success)}
 	b := PSelection(
@@ -268,3 +427,804 @@ func TestWhile(t *testing.T) {
 	expected := []State{Running, Running, Running, Failure}
 	CheckBehavior("While", t, b, expected)
 }
+
+func TestRepeatN(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Failure, Success)}
+	b := RepeatN(wrapped, 3)
+	expected := []State{Running, Running, Running, Running, Success, Success}
+	CheckBehavior("RepeatN", t, b, expected)
+	if wrapped.calls != 5 {
+		t.Error("RepeatN executed wrapped Behavior after completion", wrapped.calls)
+	}
+	if wrapped.resets != 2 {
+		t.Error("RepeatN failed to reset wrapped Behavior", wrapped.resets)
+	}
+	b.Reset()
+	CheckBehavior("RepeatN (Reset)", t, b, []State{Running, Running, Running})
+}
+
+func TestRepeatN_Zero(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running)}
+	b := RepeatN(wrapped, 0)
+	CheckBehavior("RepeatN (Zero)", t, b, []State{Success})
+	if wrapped.calls != 0 {
+		t.Error("RepeatN (Zero) executed wrapped Behavior")
+	}
+}
+
+func TestRepeatN_Unknown(t *testing.T) {
+	b := RepeatN(Recorded(Success, Unknown), 3)
+	CheckBehavior("RepeatN (Unknown)", t, b, []State{Running, Unknown})
+}
+
+func TestRetryN_Success(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Failure, Running, Failure, Success)}
+	b := RetryN(wrapped, 3)
+	expected := []State{Running, Running, Running, Success}
+	CheckBehavior("RetryN (Success)", t, b, expected)
+	if wrapped.resets != 2 {
+		t.Error("RetryN (Success) failed to reset wrapped Behavior", wrapped.resets)
+	}
+}
+
+func TestRetryN_Failure(t *testing.T) {
+	b := RetryN(Recorded(Failure, Running, Failure), 3)
+	expected := []State{Running, Running, Running, Failure}
+	CheckBehavior("RetryN (Failure)", t, b, expected)
+	b.Reset()
+	CheckBehavior("RetryN (Reset)", t, b, []State{Running, Running})
+}
+
+func TestLimit(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running)}
+	b := Limit(wrapped, 3)
+	expected := []State{Running, Running, Running, Failure, Failure}
+	CheckBehavior("Limit", t, b, expected)
+	if wrapped.calls != 3 {
+		t.Error("Limit executed wrapped Behavior past limit", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("Limit (Reset)", t, b, []State{Running})
+}
+
+func TestMaxSuccess(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Success, Failure, Success, Success)}
+	b := MaxSuccess(wrapped, 2)
+	expected := []State{Running, Success, Failure, Success, Failure}
+	CheckBehavior("MaxSuccess", t, b, expected)
+	if wrapped.resets != 4 {
+		t.Error("MaxSuccess failed to reset completed Behavior", wrapped.resets)
+	}
+	b.Reset()
+	CheckBehavior("MaxSuccess (Reset)", t, b, []State{Running, Success})
+}
+
+func TestDelay(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Failure, Success)}
+	b := Delay(wrapped, 2)
+	expected := []State{Running, Running, Failure, Success}
+	CheckBehavior("Delay", t, b, expected)
+	if wrapped.calls != 2 {
+		t.Error("Delay executed wrapped Behavior during delay", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("Delay (Reset)", t, b, []State{Running, Running, Failure})
+}
+
+func TestDelay_Zero(t *testing.T) {
+	b := Delay(Recorded(Success), 0)
+	CheckBehavior("Delay (Zero)", t, b, []State{Success})
+}
+
+func TestCooldown(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Success)}
+	b := Cooldown(wrapped, 2)
+	expected := []State{Running, Success, Failure, Failure, Running, Success, Failure}
+	CheckBehavior("Cooldown", t, b, expected)
+	if wrapped.calls != 4 {
+		t.Error("Cooldown executed wrapped Behavior during cooldown", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("Cooldown (Reset)", t, b, []State{Running})
+}
+
+func TestDebounce(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Success)}
+	b := Debounce(wrapped, 2)
+	expected := []State{Running, Success, Success, Success, Running, Success, Success}
+	CheckBehavior("Debounce", t, b, expected)
+	if wrapped.calls != 4 {
+		t.Error("Debounce executed wrapped Behavior during window", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("Debounce (Reset)", t, b, []State{Running})
+}
+
+func TestEveryN(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Success, Failure)}
+	b := EveryN(wrapped, 3)
+	expected := []State{Running, Running, Running, Success, Success, Success, Failure}
+	CheckBehavior("EveryN", t, b, expected)
+	if wrapped.calls != 3 {
+		t.Error("EveryN executed wrapped Behavior more than once per n", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("EveryN (Reset)", t, b, []State{Running})
+	if wrapped.calls != 4 {
+		t.Error("EveryN failed to execute wrapped Behavior after Reset", wrapped.calls)
+	}
+}
+
+func TestParallelN_Success(t *testing.T) {
+	child := &testBehavior{base: Recorded(Success)}
+	b := ParallelN(2,
+		Recorded(Running, Running, Success),
+		child,
+		Recorded(Running, Failure),
+	)
+	expected := []State{Running, Running, Success}
+	CheckBehavior("ParallelN (Success)", t, b, expected)
+	if child.calls != 1 {
+		t.Error("ParallelN (Success) executed completed child", child.calls)
+	}
+}
+
+func TestParallelN_Failure(t *testing.T) {
+	b := ParallelN(2,
+		Recorded(Running, Running, Success),
+		Recorded(Running, Failure),
+		Recorded(Running, Running, Failure),
+	)
+	expected := []State{Running, Running, Failure}
+	CheckBehavior("ParallelN (Failure)", t, b, expected)
+}
+
+func TestParallelN_Unknown(t *testing.T) {
+	b := ParallelN(2,
+		Recorded(Running, Success),
+		Recorded(Running, Unknown),
+	)
+	expected := []State{Running, Unknown}
+	CheckBehavior("ParallelN (Unknown)", t, b, expected)
+}
+
+func TestParallelN_Reset(t *testing.T) {
+	b := ParallelN(1, Recorded(Failure, Success))
+	CheckBehavior("ParallelN (Reset)", t, b, []State{Failure, Failure})
+	b.Reset()
+	CheckBehavior("ParallelN (Reset)", t, b, []State{Success})
+}
+
+func TestParallel(t *testing.T) {
+	cases := []struct {
+		name             string
+		success, failure Policy
+		children         []Behavior
+		expected         []State
+	}{
+		{
+			"RequireAll/RequireAll (Success)", RequireAll, RequireAll,
+			[]Behavior{Recorded(Running, Success), Recorded(Success)},
+			[]State{Running, Success},
+		},
+		{
+			"RequireAll/RequireAll (Failure)", RequireAll, RequireAll,
+			[]Behavior{Recorded(Running, Failure), Recorded(Failure)},
+			[]State{Running, Failure},
+		},
+		{
+			"RequireAll/RequireAll (Mixed)", RequireAll, RequireAll,
+			[]Behavior{Recorded(Running, Failure), Recorded(Success)},
+			[]State{Running, Failure},
+		},
+		{
+			"RequireOne/RequireOne (Success)", RequireOne, RequireOne,
+			[]Behavior{Recorded(Running, Failure), Recorded(Running, Success)},
+			[]State{Running, Failure},
+		},
+		{
+			"RequireOne/RequireOne (Failure)", RequireOne, RequireOne,
+			[]Behavior{Recorded(Running, Success), Recorded(Running, Failure)},
+			[]State{Running, Success},
+		},
+	}
+	for _, c := range cases {
+		b := Parallel(c.success, c.failure, c.children...)
+		CheckBehavior("Parallel "+c.name, t, b, c.expected)
+	}
+}
+
+func TestExecute_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	first := &testBehavior{base: Func(cancel)}
+	second := &testBehavior{base: Recorded(Success)}
+	b := Sequence(first, second)
+	if actual := Execute(ctx, b); actual != Cancelled {
+		t.Error("Execute (Cancelled) produced incorrect state:", actual)
+	}
+	if second.calls != 0 {
+		t.Error("Execute (Cancelled) ran child after cancellation")
+	}
+	if actual := Execute(context.Background(), b); actual != Success {
+		t.Error("Execute (Cancelled) failed to resume:", actual)
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Error("Execute (Cancelled) failed to resume from cancelled child")
+	}
+}
+
+func TestActionCtx(t *testing.T) {
+	type key struct{}
+	b := Sequence(ActionCtx(func(ctx context.Context) State {
+		if ctx.Value(key{}) == "value" {
+			return Success
+		}
+		return Failure
+	}))
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	if actual := Execute(ctx, b); actual != Success {
+		t.Error("ActionCtx produced incorrect state:", actual)
+	}
+	b.Reset()
+	if actual := b.Execute(); actual != Failure {
+		t.Error("ActionCtx produced incorrect state without Context:", actual)
+	}
+}
+
+func TestConditionalCtx(t *testing.T) {
+	type key struct{}
+	b := Sequence(
+		ConditionalCtx(func(ctx context.Context) bool { return ctx.Value(key{}) == "value" }),
+		ConditionalCtx(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		}),
+	)
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	if actual := Execute(ctx, b); actual != Success {
+		t.Error("ConditionalCtx produced incorrect state:", actual)
+	}
+	b.Reset()
+	if actual := b.Execute(); actual != Failure {
+		t.Error("ConditionalCtx produced incorrect state without Context:", actual)
+	}
+	cancel()
+	b.Reset()
+	if actual := Execute(ctx, b); actual != Cancelled {
+		t.Error("ConditionalCtx produced incorrect state with cancelled Context:", actual)
+	}
+	done := ConditionalCtx(func(ctx context.Context) bool { return ctx.Err() != nil })
+	if actual := done.ExecuteCtx(ctx); actual != Success {
+		t.Error("ConditionalCtx failed to call function with cancelled Context:", actual)
+	}
+}
+
+func TestAlwaysSucceed(t *testing.T) {
+	wrapped := &testBehavior{base: Sequence(Recorded(Running, Success), Recorded(Failure))}
+	b := AlwaysSucceed(wrapped)
+	CheckBehavior("AlwaysSucceed", t, b, []State{Running, Success, Success})
+	if wrapped.resets != 0 {
+		t.Error("AlwaysSucceed reset wrapped Behavior on completion")
+	}
+	b.Reset()
+	CheckBehavior("AlwaysSucceed (Reset)", t, b, []State{Running, Success})
+	if wrapped.resets != 1 {
+		t.Error("AlwaysSucceed failed to reset wrapped Behavior")
+	}
+}
+
+func TestAlwaysRunning(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Failure, Success, Unknown)}
+	b := AlwaysRunning(wrapped)
+	expected := []State{Running, Running, Running, Running}
+	CheckBehavior("AlwaysRunning", t, b, expected)
+	if wrapped.resets != 2 {
+		t.Error("AlwaysRunning failed to reset wrapped Behavior", wrapped.resets)
+	}
+	b.Reset()
+	if wrapped.resets != 3 {
+		t.Error("AlwaysRunning failed to reset wrapped Behavior on Reset")
+	}
+}
+
+func TestWaitTicks(t *testing.T) {
+	b := WaitTicks(2)
+	expected := []State{Running, Running, Success, Success}
+	CheckBehavior("WaitTicks", t, b, expected)
+	b.Reset()
+	CheckBehavior("WaitTicks (Reset)", t, b, expected)
+}
+
+func TestWaitTicks_Zero(t *testing.T) {
+	CheckBehavior("WaitTicks (Zero)", t, WaitTicks(0), []State{Success})
+	CheckBehavior("WaitTicks (Negative)", t, WaitTicks(-1), []State{Success})
+}
+
+func TestReactiveSequence_Success(t *testing.T) {
+	guard := &testBehavior{base: Recorded(Success)}
+	b := ReactiveSequence(
+		guard,
+		Recorded(Running, Success, Success),
+		Recorded(Running, Success),
+	)
+	expected := []State{Running, Running, Success}
+	CheckBehavior("ReactiveSequence (Success)", t, b, expected)
+	if guard.calls != 3 {
+		t.Error("ReactiveSequence (Success) failed to re-evaluate first child", guard.calls)
+	}
+}
+
+func TestReactiveSequence_Failure(t *testing.T) {
+	body := &testBehavior{base: Recorded(Running)}
+	b := ReactiveSequence(
+		Recorded(Success, Success, Failure),
+		body,
+	)
+	expected := []State{Running, Running, Failure}
+	CheckBehavior("ReactiveSequence (Failure)", t, b, expected)
+	if body.calls != 2 {
+		t.Error("ReactiveSequence (Failure) executed child after failure", body.calls)
+	}
+	if body.resets != 1 {
+		t.Error("ReactiveSequence (Failure) failed to reset aborted child", body.resets)
+	}
+}
+
+func TestReactiveSequence_Unknown(t *testing.T) {
+	b := ReactiveSequence(Recorded(Success), Recorded(Running, Unknown))
+	expected := []State{Running, Unknown}
+	CheckBehavior("ReactiveSequence (Unknown)", t, b, expected)
+}
+
+func TestReactiveSelection_Success(t *testing.T) {
+	b := ReactiveSelection(
+		Recorded(Failure),
+		Recorded(Failure, Running, Success),
+		Recorded(Running),
+	)
+	expected := []State{Running, Running, Success}
+	CheckBehavior("ReactiveSelection (Success)", t, b, expected)
+}
+
+func TestReactiveSelection_Failure(t *testing.T) {
+	b := ReactiveSelection(
+		Recorded(Failure),
+		Recorded(Running, Failure),
+	)
+	expected := []State{Running, Failure}
+	CheckBehavior("ReactiveSelection (Failure)", t, b, expected)
+}
+
+func TestReactiveSelection_Preempt(t *testing.T) {
+	low := &testBehavior{base: Recorded(Running)}
+	b := ReactiveSelection(
+		Recorded(Failure, Failure, Running),
+		low,
+	)
+	expected := []State{Running, Running, Running}
+	CheckBehavior("ReactiveSelection (Preempt)", t, b, expected)
+	if low.calls != 2 {
+		t.Error("ReactiveSelection (Preempt) executed preempted child", low.calls)
+	}
+	if low.resets != 1 {
+		t.Error("ReactiveSelection (Preempt) failed to reset preempted child", low.resets)
+	}
+}
+
+func TestReactiveSelection_Unknown(t *testing.T) {
+	b := ReactiveSelection(Recorded(Failure), Recorded(Running, Unknown))
+	expected := []State{Running, Unknown}
+	CheckBehavior("ReactiveSelection (Unknown)", t, b, expected)
+}
+
+func TestSwitch(t *testing.T) {
+	index, calls := 1, 0
+	selector := func() int {
+		calls++
+		return index
+	}
+	b := Switch(selector, Recorded(Failure), Recorded(Running, Success))
+	CheckBehavior("Switch", t, b, []State{Running, Success})
+	if calls != 1 {
+		t.Error("Switch called selector more than once per activation", calls)
+	}
+	index = 0
+	b.Reset()
+	CheckBehavior("Switch (Reset)", t, b, []State{Failure})
+	if calls != 2 {
+		t.Error("Switch failed to call selector after Reset", calls)
+	}
+}
+
+func TestSwitch_OutOfRange(t *testing.T) {
+	for _, index := range []int{-1, 2} {
+		b := Switch(func() int { return index }, Recorded(Success), Recorded(Success))
+		CheckBehavior(fmt.Sprintf("Switch (%d)", index), t, b, []State{Failure})
+	}
+}
+
+func TestAutoReset(t *testing.T) {
+	first := &testBehavior{base: Recorded(Success)}
+	b := AutoReset(Sequence(first, Recorded(Running, Failure, Success)))
+	expected := []State{Running, Failure, Success, Running}
+	CheckBehavior("AutoReset", t, b, expected)
+	if first.calls != 3 {
+		t.Error("AutoReset failed to restart from first child", first.calls)
+	}
+	if first.resets != 2 {
+		t.Error("AutoReset failed to reset after completion", first.resets)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	visible := true
+	b := Memoize(func() bool {
+		calls++
+		return visible
+	})
+	CheckBehavior("Memoize", t, b, []State{Success, Success})
+	visible = false
+	CheckBehavior("Memoize", t, b, []State{Success})
+	if calls != 1 {
+		t.Error("Memoize evaluated Conditional more than once", calls)
+	}
+	b.Reset()
+	CheckBehavior("Memoize (Reset)", t, b, []State{Failure, Failure})
+	if calls != 2 {
+		t.Error("Memoize failed to clear cache on Reset", calls)
+	}
+}
+
+func TestNot(t *testing.T) {
+	hasTarget := Conditional(func() bool { return true })
+	b := Not(hasTarget)
+	if _, ok := b.(Conditional); !ok {
+		t.Error("Not failed to negate Conditional directly")
+	}
+	CheckBehavior("Not (Conditional)", t, b, []State{Failure})
+	b = Not(Recorded(Running, Success, Failure, Unknown))
+	expected := []State{Running, Failure, Success, Unknown}
+	CheckBehavior("Not (Behavior)", t, b, expected)
+}
+
+func TestAnd(t *testing.T) {
+	called := false
+	b := And(
+		func() bool { return true },
+		func() bool { return false },
+		func() bool {
+			called = true
+			return true
+		},
+	)
+	CheckBehavior("And", t, b, []State{Failure})
+	if called {
+		t.Error("And failed to short-circuit")
+	}
+	CheckBehavior("And (True)", t, And(func() bool { return true }), []State{Success})
+	CheckBehavior("And (Empty)", t, And(), []State{Success})
+}
+
+func TestOr(t *testing.T) {
+	called := false
+	b := Or(
+		func() bool { return false },
+		func() bool { return true },
+		func() bool {
+			called = true
+			return false
+		},
+	)
+	CheckBehavior("Or", t, b, []State{Success})
+	if called {
+		t.Error("Or failed to short-circuit")
+	}
+	CheckBehavior("Or (False)", t, Or(func() bool { return false }), []State{Failure})
+	CheckBehavior("Or (Empty)", t, Or(), []State{Failure})
+}
+
+func TestOnce(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Failure, Success)}
+	b := Once(wrapped)
+	expected := []State{Running, Failure, Failure, Failure}
+	CheckBehavior("Once", t, b, expected)
+	if wrapped.calls != 2 {
+		t.Error("Once executed wrapped Behavior after latching", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("Once (Reset)", t, b, []State{Success, Success})
+	if wrapped.calls != 3 {
+		t.Error("Once executed wrapped Behavior after latching", wrapped.calls)
+	}
+}
+
+func TestEdgeTrigger(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Failure, Running, Success, Success)}
+	b := EdgeTrigger(wrapped)
+	expected := []State{Failure, Running, Success, Failure, Failure}
+	CheckBehavior("EdgeTrigger", t, b, expected)
+	if wrapped.calls != 3 {
+		t.Error("EdgeTrigger executed wrapped Behavior after firing", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("EdgeTrigger (Reset)", t, b, []State{Success, Failure})
+}
+
+func TestEdgeTrigger_Distinctions(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(Behavior) Behavior
+		expected []State
+	}{
+		{"Once", Once, []State{Success, Success, Success, Success}},
+		{"EdgeTrigger", EdgeTrigger, []State{Success, Failure, Failure, Failure}},
+		{"Debounce", func(b Behavior) Behavior { return Debounce(b, 1) }, []State{Success, Success, Failure, Success}},
+	}
+	for _, c := range cases {
+		b := c.fn(Recorded(Success, Failure))
+		CheckBehavior(c.name+" (Distinctions)", t, b, c.expected)
+	}
+}
+
+func TestRef(t *testing.T) {
+	calls := 0
+	child := &testBehavior{base: Recorded(Running, Success)}
+	b := Ref(func() Behavior {
+		calls++
+		return child
+	})
+	if _, err := ToDOT(b); err != nil || calls != 0 {
+		t.Error("Ref called provider before execution")
+	}
+	CheckBehavior("Ref", t, b, []State{Running, Success})
+	b.Reset()
+	CheckBehavior("Ref (Reset)", t, b, []State{Running})
+	if calls != 1 {
+		t.Error("Ref called provider more than once", calls)
+	}
+	if child.resets != 1 {
+		t.Error("Ref failed to reset underlying Behavior", child.resets)
+	}
+}
+
+func TestRef_Recursive(t *testing.T) {
+	depth := 0
+	var countdown func() Behavior
+	countdown = func() Behavior {
+		return Selection(
+			Conditional(func() bool { return depth >= 3 }),
+			Sequence(Func(func() { depth++ }), Ref(countdown)),
+		)
+	}
+	b := countdown()
+	CheckBehavior("Ref (Recursive)", t, b, []State{Success})
+	if depth != 3 {
+		t.Error("Ref (Recursive) recursed incorrectly", depth)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	b := Lazy(func() Behavior {
+		calls++
+		return Sequence(WaitTicks(1), Recorded(Success))
+	})
+	root := Selection(Recorded(Success, Failure), b)
+	CheckBehavior("Lazy (Unreached)", t, root, []State{Success})
+	if calls != 0 {
+		t.Error("Lazy constructed Behavior before execution", calls)
+	}
+	root.Reset()
+	CheckBehavior("Lazy", t, root, []State{Running, Success})
+	if calls != 1 {
+		t.Error("Lazy constructed Behavior more than once per activation", calls)
+	}
+	root.Reset()
+	if len(b.(Composite).Children()) != 0 {
+		t.Error("Lazy failed to discard Behavior on Reset")
+	}
+	CheckBehavior("Lazy (Reset)", t, b, []State{Running})
+	if calls != 2 {
+		t.Error("Lazy failed to construct Behavior again after Reset", calls)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	conds := []bool{true, true, false, true, true, true}
+	i := 0
+	cond := Conditional(func() bool {
+		i++
+		return conds[i-1]
+	})
+	body := &testBehavior{base: Recorded(Running, Running, Success, Running, Success)}
+	b := Guard(cond, body)
+	expected := []State{Running, Running, Failure, Success, Running, Success}
+	CheckBehavior("Guard", t, b, expected)
+	if body.calls != 5 {
+		t.Error("Guard ran body while condition failed", body.calls)
+	}
+	if body.resets != 1 {
+		t.Error("Guard failed to reset body when condition failed", body.resets)
+	}
+}
+
+func TestCheckpointSequence(t *testing.T) {
+	first := &testBehavior{base: Recorded(Success)}
+	saved := &testBehavior{base: Recorded(Success, Success)}
+	flaky := &testBehavior{base: Recorded(Failure, Success)}
+	b := CheckpointSequence(first, Checkpoint(saved), flaky, Recorded(Success))
+	CheckBehavior("CheckpointSequence", t, b, []State{Running, Success})
+	if first.calls != 1 {
+		t.Error("CheckpointSequence rolled back past the checkpoint")
+	}
+	if saved.calls != 2 || saved.resets != 1 || flaky.resets != 1 {
+		t.Error("CheckpointSequence failed to retry from the checkpoint")
+	}
+}
+
+func TestCheckpointSequence_Wrapped(t *testing.T) {
+	build := func(mark func(Behavior) Behavior) Behavior {
+		return CheckpointSequence(
+			Recorded(Success),
+			mark(Checkpoint(Recorded(Success, Success))),
+			Recorded(Failure, Success),
+		)
+	}
+	same := func(b Behavior) Behavior { return b }
+	named := func(b Behavior) Behavior { return Named("checkpoint", b) }
+	tagged := func(b Behavior) Behavior { return Tag(b, "checkpoint") }
+	instrumented, _ := Instrument(build(same))
+	traced, _ := TraceTree(build(same))
+	cases := map[string]Behavior{
+		"Instrument": instrumented,
+		"TraceTree":  traced,
+		"Named":      build(named),
+		"Tag":        build(tagged),
+	}
+	for name, b := range cases {
+		CheckBehavior("CheckpointSequence ("+name+")", t, b, []State{Running, Success})
+	}
+}
+
+func TestCheckpointSequence_Nested(t *testing.T) {
+	outer := &testBehavior{base: Recorded(Success, Success)}
+	inner := &testBehavior{base: Recorded(Success, Failure, Success)}
+	b := CheckpointSequence(
+		Checkpoint(outer),
+		Checkpoint(inner),
+		Recorded(Failure, Success),
+	)
+	expected := []State{Running, Running, Success}
+	CheckBehavior("CheckpointSequence (Nested)", t, b, expected)
+	if outer.calls != 2 || inner.calls != 3 {
+		t.Error("CheckpointSequence failed to roll back past a failed checkpoint")
+	}
+}
+
+func TestCheckpointSequence_Failure(t *testing.T) {
+	b := CheckpointSequence(Recorded(Success), Recorded(Failure), Checkpoint(Recorded(Success)))
+	CheckBehavior("CheckpointSequence (Failure)", t, b, []State{Failure})
+}
+
+func TestFork(t *testing.T) {
+	main := &testBehavior{base: Recorded(Running, Failure, Success)}
+	side := &testBehavior{base: Recorded(Running, Success, Failure)}
+	b := Fork(main, side)
+	CheckBehavior("Fork", t, b, []State{Running, Failure, Success})
+	if side.calls != 3 {
+		t.Error("Fork failed to run side every execution", side.calls)
+	}
+	if side.resets != 2 {
+		t.Error("Fork failed to reset completed side", side.resets)
+	}
+	b.Reset()
+	if main.resets != 1 || side.resets != 3 {
+		t.Error("Fork failed to reset both children")
+	}
+	b = Fork(Recorded(Running, Success), Recorded(Running, Success))
+	for i, expected := range []int{0, 2} {
+		b.Execute()
+		if current, total := b.(Progress).Progress(); current != expected || total != 2 {
+			t.Errorf("Fork reported progress %d/%d after execution %d", current, total, i+1)
+		}
+	}
+}
+
+func TestStreak(t *testing.T) {
+	readings := []bool{true, true, false, true, true, true, true, false, true}
+	i := 0
+	cond := Conditional(func() bool {
+		i++
+		return readings[i-1]
+	})
+	b := Streak(cond, 3)
+	expected := []State{
+		Failure, Failure, Failure,
+		Failure, Failure, Success, Success,
+		Failure, Failure,
+	}
+	CheckBehavior("Streak", t, b, expected)
+	readings, i = []bool{true, true, true, true}, 0
+	b.Execute()
+	b.Reset()
+	CheckBehavior("Streak (Reset)", t, b, []State{Failure, Failure, Success})
+}
+
+func TestMirror(t *testing.T) {
+	calls := 0
+	visible := Named("visible", Conditional(func() bool {
+		calls++
+		return calls == 1
+	}))
+	b := Sequence(visible, Recorded(Success), Mirror(visible))
+	CheckBehavior("Mirror", t, b, []State{Success})
+	if calls != 1 {
+		t.Error("Mirror executed its target", calls)
+	}
+	visible.Reset()
+	CheckBehavior("Mirror (Unrun)", t, Mirror(visible), []State{Failure})
+	CheckBehavior("Mirror (Stateless)", t, Mirror(Recorded(Success)), []State{Failure})
+	b = Selection(Sequence(visible, Recorded(Success)), Invert(Mirror(visible)))
+	CheckBehavior("Mirror (Failure)", t, b, []State{Success})
+}
+
+func TestGate(t *testing.T) {
+	enabled := true
+	body := &testBehavior{base: Recorded(Running, Running, Success, Running, Success)}
+	b := Gate(body, &enabled)
+	flips := []bool{true, true, false, false, true, true, true}
+	expected := []State{Running, Running, Failure, Failure, Success, Running, Success}
+	for i, flip := range flips {
+		enabled = flip
+		if actual := b.Execute(); actual != expected[i] {
+			t.Errorf("Gate produced incorrect result on tick %d: %v", i, actual)
+		}
+	}
+	if body.calls != 5 {
+		t.Error("Gate ran body while disabled", body.calls)
+	}
+	if body.resets != 1 {
+		t.Error("Gate failed to reset Running body once when disabled", body.resets)
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	cases := []struct {
+		name     string
+		b        Behavior
+		expected State
+	}{
+		{"Sequence", Sequence(), Success},
+		{"Selection", Selection(), Failure},
+		{"ReactiveSequence", ReactiveSequence(), Success},
+		{"ReactiveSelection", ReactiveSelection(), Failure},
+		{"PSequence", PSequence(), Success},
+		{"PSelection", PSelection(), Failure},
+		{"Parallel", Parallel(RequireOne, RequireOne), Failure},
+		{"AtLeast", AtLeast(0), Success},
+	}
+	for _, c := range cases {
+		CheckBehavior(c.name+" (Empty)", t, c.b, []State{c.expected, c.expected})
+	}
+}
+
+func TestCancelled(t *testing.T) {
+	if Cancelled.String() != "Cancelled" {
+		t.Error("Cancelled has incorrect String:", Cancelled.String())
+	}
+	cases := map[string]Behavior{
+		"Sequence":      Sequence(Recorded(Success), Recorded(Cancelled), Recorded(Success)),
+		"Selection":     Selection(Recorded(Failure), Recorded(Cancelled), Recorded(Success)),
+		"PSequence":     PSequence(Recorded(Running), Recorded(Cancelled)),
+		"SequenceAll":   SequenceAll(Recorded(Failure), Recorded(Cancelled)),
+		"Invert":        Invert(Recorded(Cancelled)),
+		"Repeat":        Repeat(Recorded(Cancelled)),
+		"AlwaysRunning": AlwaysRunning(Recorded(Cancelled)),
+		"RetryN":        RetryN(Recorded(Cancelled), 2),
+	}
+	for name, b := range cases {
+		CheckBehavior(name+" (Cancelled)", t, b, []State{Cancelled})
+	}
+}
@@ -0,0 +1,118 @@
+package bt
+
+import "context"
+
+// Blackboard is a keyed store of data shared between Behavior. The zero value
+// is an empty Blackboard ready to use. It is not safe for concurrent use.
+type Blackboard struct {
+	data map[string]any
+}
+
+// Get gets the value stored under key, and whether there was one.
+func (bb *Blackboard) Get(key string) (any, bool) {
+	v, ok := bb.data[key]
+	return v, ok
+}
+
+// Set stores v under key, replacing any existing value.
+func (bb *Blackboard) Set(key string, v any) {
+	if bb.data == nil {
+		bb.data = make(map[string]any)
+	}
+	bb.data[key] = v
+}
+
+// Delete removes any value stored under key.
+func (bb *Blackboard) Delete(key string) {
+	delete(bb.data, key)
+}
+
+// blackboardKey is the Context key for a Blackboard.
+type blackboardKey struct{}
+
+// WithBlackboard gets a copy of ctx which carries bb to the Behavior executed
+// with it.
+func WithBlackboard(ctx context.Context, bb *Blackboard) context.Context {
+	return context.WithValue(ctx, blackboardKey{}, bb)
+}
+
+// BlackboardFrom gets the Blackboard carried by ctx, or nil if there is none.
+func BlackboardFrom(ctx context.Context) *Blackboard {
+	bb, _ := ctx.Value(blackboardKey{}).(*Blackboard)
+	return bb
+}
+
+// ActionBB is a function of a Blackboard which acts as a Behavior.
+type ActionBB func(*Blackboard) State
+
+// Reset is a noop.
+func (ActionBB) Reset() {}
+
+// Execute returns Unknown, since there is no Blackboard without a Context.
+func (ActionBB) Execute() State { return Unknown }
+
+// ExecuteCtx calls the underlying function with the Blackboard carried by ctx
+// and returns the result. It returns Unknown if ctx carries no Blackboard.
+func (a ActionBB) ExecuteCtx(ctx context.Context) State {
+	bb := BlackboardFrom(ctx)
+	if bb == nil {
+		return Unknown
+	}
+	return a(bb)
+}
+
+// ConditionalBB is a bool function of a Blackboard which acts as a Behavior.
+type ConditionalBB func(*Blackboard) bool
+
+// Reset is a noop.
+func (ConditionalBB) Reset() {}
+
+// Execute returns Unknown, since there is no Blackboard without a Context.
+func (ConditionalBB) Execute() State { return Unknown }
+
+// ExecuteCtx calls the function with the Blackboard carried by ctx, returning
+// Success if true, or Failure otherwise. It returns Unknown if ctx carries no
+// Blackboard.
+func (c ConditionalBB) ExecuteCtx(ctx context.Context) State {
+	bb := BlackboardFrom(ctx)
+	if bb == nil {
+		return Unknown
+	}
+	if c(bb) {
+		return Success
+	}
+	return Failure
+}
+
+// Produce gets a Behavior which calls fn and returns the State it produces. On
+// Success, the value produced is also stored under key in the Blackboard
+// carried by the Context, so that a later Consume can read it. Like ActionBB,
+// it returns Unknown if executed without a Blackboard.
+func Produce[T any](key string, fn func() (T, State)) Behavior {
+	return ActionBB(func(bb *Blackboard) State {
+		v, s := fn()
+		if s == Success {
+			bb.Set(key, v)
+		}
+		return s
+	})
+}
+
+// Consume gets a Behavior which calls fn with the value of type T stored under
+// key in the Blackboard carried by the Context, such as by Produce, and returns
+// the result. If the key is missing, or holds a value which is not a T, fn is
+// not called and it fails. Like ActionBB, it returns Unknown if executed
+// without a Blackboard.
+func Consume[T any](key string, fn func(T) State) Behavior {
+	return ActionBB(func(bb *Blackboard) State {
+		v, ok := bb.Get(key)
+		if !ok {
+			return Failure
+		}
+		t, ok := v.(T)
+		if !ok {
+			return Failure
+		}
+		return fn(t)
+	})
+}
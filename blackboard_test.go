@@ -0,0 +1,77 @@
+package bt
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBlackboard(t *testing.T) {
+	var bb Blackboard
+	if _, ok := bb.Get("x"); ok {
+		t.Error("Blackboard produced value for missing key")
+	}
+	bb.Set("x", 1)
+	if v, ok := bb.Get("x"); !ok || v != 1 {
+		t.Error("Blackboard produced incorrect value:", v)
+	}
+	bb.Delete("x")
+	if _, ok := bb.Get("x"); ok {
+		t.Error("Blackboard failed to delete key")
+	}
+}
+
+func TestActionBB(t *testing.T) {
+	write := ActionBB(func(bb *Blackboard) State {
+		bb.Set("target", "door")
+		return Success
+	})
+	read := ConditionalBB(func(bb *Blackboard) bool {
+		v, _ := bb.Get("target")
+		return v == "door"
+	})
+	b := Sequence(Invert(Invert(write)), read)
+	bb := &Blackboard{}
+	if actual := Execute(WithBlackboard(context.Background(), bb), b); actual != Success {
+		t.Error("ActionBB produced incorrect state:", actual)
+	}
+	if v, _ := bb.Get("target"); v != "door" {
+		t.Error("ActionBB failed to write Blackboard")
+	}
+}
+
+func TestActionBB_Missing(t *testing.T) {
+	b := Sequence(ConditionalBB(func(*Blackboard) bool { return true }))
+	if actual := b.Execute(); actual != Unknown {
+		t.Error("ConditionalBB produced incorrect state without Blackboard:", actual)
+	}
+}
+
+func TestProduceConsume(t *testing.T) {
+	var got int
+	consume := Consume("path", func(n int) State {
+		got = n
+		return Success
+	})
+	b := Sequence(
+		Produce("path", func() (int, State) { return 7, Success }),
+		consume,
+	)
+	bb := &Blackboard{}
+	ctx := WithBlackboard(context.Background(), bb)
+	if actual := Execute(ctx, b); actual != Success || got != 7 {
+		t.Error("Produce failed to pass value to Consume:", actual, got)
+	}
+	failed := Produce("failed", func() (int, State) { return 1, Failure })
+	if actual := Execute(ctx, failed); actual != Failure {
+		t.Error("Produce produced incorrect state:", actual)
+	}
+	if _, ok := bb.Get("failed"); ok {
+		t.Error("Produce stored value without Success")
+	}
+	if actual := Execute(ctx, Consume("failed", func(int) State { return Success })); actual != Failure {
+		t.Error("Consume produced incorrect state for missing key:", actual)
+	}
+	if actual := Execute(ctx, Consume("path", func(string) State { return Success })); actual != Failure {
+		t.Error("Consume produced incorrect state for mistyped value:", actual)
+	}
+}
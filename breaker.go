@@ -0,0 +1,86 @@
+package bt
+
+import (
+	"context"
+	"time"
+)
+
+// circuitBreaker is a Behavior which stops running another Behavior after it
+// fails too many times in a row.
+type circuitBreaker struct {
+	wrapper
+	threshold, failures int
+	open                bool
+	cooldown            time.Duration
+	clock               Clock
+	opened              time.Time
+}
+
+// CircuitBreaker wraps a Behavior so that each execution is a fresh attempt,
+// with the underlying Behavior reset whenever it succeeds or fails. Consecutive
+// failures are counted, and a Success zeroes the count. Once threshold
+// consecutive failures are reached, the breaker opens, and fails without
+// executing the underlying Behavior. The breaker and its failure count
+// deliberately survive Reset, so that it stays open across activations; to
+// close it again, use Rewind.
+func CircuitBreaker(b Behavior, threshold int) Behavior {
+	return &circuitBreaker{wrapper: wrapper{node: b}, threshold: threshold}
+}
+
+// Rewind closes the breaker, zeroes the failure count, and resets the
+// underlying Behavior.
+func (c *circuitBreaker) Rewind() {
+	c.failures = 0
+	c.open = false
+	c.Reset()
+}
+
+// CircuitBreakerCooldown is like CircuitBreaker, except that once the breaker
+// has been open for the given cooldown, it is half-open, and lets a single probe
+// of the underlying Behavior through. If the probe succeeds, the breaker closes;
+// if it fails, the breaker opens again for another cooldown. While the probe is
+// Running, it continues to be executed.
+func CircuitBreakerCooldown(b Behavior, threshold int, cooldown time.Duration) Behavior {
+	return CircuitBreakerCooldownClock(b, threshold, cooldown, SystemClock)
+}
+
+// CircuitBreakerCooldownClock is like CircuitBreakerCooldown, but uses clock to
+// get the current time.
+func CircuitBreakerCooldownClock(b Behavior, threshold int, cooldown time.Duration, clock Clock) Behavior {
+	return &circuitBreaker{wrapper: wrapper{node: b}, threshold: threshold, cooldown: cooldown, clock: clock}
+}
+
+// halfOpen reports whether the breaker is open but its cooldown has elapsed.
+func (c *circuitBreaker) halfOpen() bool {
+	return c.open && c.clock != nil && c.clock.Now().Sub(c.opened) >= c.cooldown
+}
+
+// Execute fails while the breaker is open, and otherwise runs the underlying
+// Behavior and returns the result.
+func (c *circuitBreaker) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (c *circuitBreaker) ExecuteCtx(ctx context.Context) State {
+	if c.open && !c.halfOpen() {
+		return Failure
+	}
+	s := Execute(ctx, c.node)
+	switch s {
+	case Success:
+		c.failures = 0
+		c.open = false
+		c.node.Reset()
+	case Failure:
+		c.failures++
+		if c.open || c.failures >= c.threshold {
+			c.open = true
+			if c.clock != nil {
+				c.opened = c.clock.Now()
+			}
+		}
+		c.node.Reset()
+	}
+	return s
+}
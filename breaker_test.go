@@ -0,0 +1,65 @@
+package bt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Failure, Success, Failure, Running, Failure, Failure, Success)}
+	b := CircuitBreaker(wrapped, 2)
+	expected := []State{Failure, Success, Failure, Running, Failure, Failure, Failure}
+	CheckBehavior("CircuitBreaker", t, b, expected)
+	if wrapped.calls != 5 {
+		t.Error("CircuitBreaker executed wrapped Behavior while open", wrapped.calls)
+	}
+	if wrapped.resets != 4 {
+		t.Error("CircuitBreaker failed to reset wrapped Behavior after each attempt", wrapped.resets)
+	}
+	b.Reset()
+	CheckBehavior("CircuitBreaker (Reset)", t, b, []State{Failure})
+	if wrapped.calls != 5 {
+		t.Error("CircuitBreaker closed on Reset", wrapped.calls)
+	}
+	b.(Rewinder).Rewind()
+	CheckBehavior("CircuitBreaker (Rewind)", t, b, []State{Failure, Success})
+	if wrapped.calls != 7 {
+		t.Error("CircuitBreaker failed to close on Rewind", wrapped.calls)
+	}
+}
+
+func TestCircuitBreaker_Runner(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Failure)}
+	r := NewRunner(CircuitBreaker(wrapped, 2))
+	for i := 0; i < 4; i++ {
+		if s := r.Tick(); s != Failure {
+			t.Error("CircuitBreaker under Runner produced", s)
+		}
+	}
+	if wrapped.calls != 2 {
+		t.Error("CircuitBreaker closed when reset by Runner", wrapped.calls)
+	}
+}
+
+func TestCircuitBreakerCooldown(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	wrapped := &testBehavior{base: Recorded(Failure, Failure, Failure, Running, Success, Failure)}
+	b := CircuitBreakerCooldownClock(wrapped, 2, 3*time.Second, clock)
+	CheckBehavior("CircuitBreakerCooldown (Closed)", t, b, []State{Failure, Failure})
+	clock.Advance(2 * time.Second)
+	CheckBehavior("CircuitBreakerCooldown (Open)", t, b, []State{Failure})
+	if wrapped.calls != 2 {
+		t.Error("CircuitBreakerCooldown executed wrapped Behavior while open", wrapped.calls)
+	}
+	clock.Advance(time.Second)
+	CheckBehavior("CircuitBreakerCooldown (Probe Failure)", t, b, []State{Failure, Failure})
+	if wrapped.calls != 3 {
+		t.Error("CircuitBreakerCooldown failed to reopen after failed probe", wrapped.calls)
+	}
+	clock.Advance(3 * time.Second)
+	CheckBehavior("CircuitBreakerCooldown (Probe Success)", t, b, []State{Running, Success})
+	CheckBehavior("CircuitBreakerCooldown (Closed)", t, b, []State{Failure})
+	if wrapped.calls != 6 {
+		t.Error("CircuitBreakerCooldown failed to close after successful probe", wrapped.calls)
+	}
+}
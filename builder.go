@@ -0,0 +1,161 @@
+package bt
+
+// frame is a composite under construction by a Builder.
+type frame struct {
+	ctor     func(...Behavior) Behavior
+	children []Behavior
+	wrap     []func(Behavior) Behavior
+}
+
+// Builder constructs a tree fluently. Composites are opened by methods such as
+// Sequence and closed by End, leaves are added by Child, and decorators such as
+// Named or Invert apply to whichever Behavior is added next. The resulting
+// tree is made of the same Behavior the constructors produce. A Builder panics
+// if it is misused, such as by closing a composite which was never opened.
+type Builder struct {
+	stack []*frame
+	wrap  []func(Behavior) Behavior
+	root  Behavior
+}
+
+// New gets an empty Builder.
+func New() *Builder {
+	return &Builder{}
+}
+
+// Composite opens a composite which will be constructed by ctor once End is
+// called.
+func (b *Builder) Composite(ctor func(...Behavior) Behavior) *Builder {
+	b.stack = append(b.stack, &frame{ctor: ctor, wrap: b.wrap})
+	b.wrap = nil
+	return b
+}
+
+// Sequence opens a Sequence.
+func (b *Builder) Sequence() *Builder {
+	return b.Composite(Sequence)
+}
+
+// Selection opens a Selection.
+func (b *Builder) Selection() *Builder {
+	return b.Composite(Selection)
+}
+
+// ReactiveSequence opens a ReactiveSequence.
+func (b *Builder) ReactiveSequence() *Builder {
+	return b.Composite(ReactiveSequence)
+}
+
+// ReactiveSelection opens a ReactiveSelection.
+func (b *Builder) ReactiveSelection() *Builder {
+	return b.Composite(ReactiveSelection)
+}
+
+// PSequence opens a PSequence.
+func (b *Builder) PSequence() *Builder {
+	return b.Composite(PSequence)
+}
+
+// PSelection opens a PSelection.
+func (b *Builder) PSelection() *Builder {
+	return b.Composite(PSelection)
+}
+
+// End closes the most recently opened composite.
+func (b *Builder) End() *Builder {
+	if len(b.stack) == 0 {
+		panic("bt: Builder.End without an open composite")
+	}
+	if len(b.wrap) != 0 {
+		panic("bt: Builder.End with a decorator but no Behavior to decorate")
+	}
+	f := b.stack[len(b.stack)-1]
+	b.stack = b.stack[:len(b.stack)-1]
+	b.add(f.ctor(f.children...), f.wrap)
+	return b
+}
+
+// Child adds leaf Behavior to the open composite.
+func (b *Builder) Child(bs ...Behavior) *Builder {
+	for _, n := range bs {
+		b.add(n, b.wrap)
+		b.wrap = nil
+	}
+	return b
+}
+
+// Decorate wraps the next Behavior added with fn. Decorators apply outermost
+// first, so New().Named("x").Invert().Child(a) builds Named("x", Invert(a)).
+func (b *Builder) Decorate(fn func(Behavior) Behavior) *Builder {
+	b.wrap = append(b.wrap, fn)
+	return b
+}
+
+// Named gives the next Behavior added a name.
+func (b *Builder) Named(name string) *Builder {
+	return b.Decorate(func(n Behavior) Behavior { return Named(name, n) })
+}
+
+// Invert inverts the next Behavior added.
+func (b *Builder) Invert() *Builder {
+	return b.Decorate(Invert)
+}
+
+// Repeat repeats the next Behavior added.
+func (b *Builder) Repeat() *Builder {
+	return b.Decorate(Repeat)
+}
+
+// Until repeats the next Behavior added until it succeeds.
+func (b *Builder) Until() *Builder {
+	return b.Decorate(Until)
+}
+
+// While repeats the next Behavior added while it succeeds.
+func (b *Builder) While() *Builder {
+	return b.Decorate(While)
+}
+
+// ForceSuccess forces the next Behavior added to succeed.
+func (b *Builder) ForceSuccess() *Builder {
+	return b.Decorate(ForceSuccess)
+}
+
+// ForceFailure forces the next Behavior added to fail.
+func (b *Builder) ForceFailure() *Builder {
+	return b.Decorate(ForceFailure)
+}
+
+// AutoReset automatically resets the next Behavior added.
+func (b *Builder) AutoReset() *Builder {
+	return b.Decorate(AutoReset)
+}
+
+// Build gets the constructed tree. It panics if any composite is still open or
+// nothing was added.
+func (b *Builder) Build() Behavior {
+	if len(b.stack) != 0 {
+		panic("bt: Builder.Build with an open composite")
+	}
+	if b.root == nil {
+		panic("bt: Builder.Build with no Behavior")
+	}
+	return b.root
+}
+
+// add applies the decorators in wrap to n, and then adds the result to the
+// open composite, or makes it the root.
+func (b *Builder) add(n Behavior, wrap []func(Behavior) Behavior) {
+	for i := len(wrap) - 1; i >= 0; i-- {
+		n = wrap[i](n)
+	}
+	if len(b.stack) == 0 {
+		if b.root != nil {
+			panic("bt: Builder with more than one root")
+		}
+		b.root = n
+		return
+	}
+	f := b.stack[len(b.stack)-1]
+	f.children = append(f.children, n)
+}
@@ -0,0 +1,51 @@
+package bt
+
+import (
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	a, b, c := Recorded(Success), Recorded(Running, Success), Recorded(Success)
+	built := New().
+		Named("root").Selection().
+		Invert().Child(a).
+		Sequence().
+		Child(b).
+		Named("c").ForceFailure().Child(c).
+		End().
+		End().
+		Build()
+	expected := Named("root", Selection(
+		Invert(a),
+		Sequence(b, Named("c", ForceFailure(c))),
+	))
+	got, err := ToDOT(built)
+	if err != nil {
+		t.Fatal("ToDOT produced unexpected error:", err)
+	}
+	want, _ := ToDOT(expected)
+	if got != want {
+		t.Errorf("Builder produced incorrect tree:\n%s", got)
+	}
+	CheckBehavior("Builder", t, built, []State{Running, Failure})
+}
+
+func TestBuilder_Misuse(t *testing.T) {
+	cases := map[string]func(){
+		"End":      func() { New().End() },
+		"Open":     func() { New().Sequence().Build() },
+		"Empty":    func() { New().Build() },
+		"Roots":    func() { New().Child(Recorded(Success), Recorded(Success)) },
+		"Dangling": func() { New().Sequence().Invert().End() },
+	}
+	for name, fn := range cases {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Builder failed to panic on misuse:", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
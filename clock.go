@@ -0,0 +1,56 @@
+package bt
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time for time-based Behavior.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function which acts as a Clock.
+type ClockFunc func() time.Time
+
+// Now calls the function.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock used by time-based Behavior unless another is given.
+// It reports the real time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// ManualClock is a Clock which only changes when told to, so that time-based
+// Behavior can be tested deterministically. It is safe for concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock gets a ManualClock which starts at the given time.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now gets the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
@@ -0,0 +1,494 @@
+package bt
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Cloner is a Behavior which can copy itself.
+type Cloner interface {
+	Clone() Behavior
+}
+
+// Clone gets a deep copy of the tree rooted at b, including the current state
+// of each node, so that the copy can be executed and reset independently of
+// the original. Behavior which are not Cloner, such as Action and Conditional,
+// are shared rather than copied, so any state captured by their functions is
+// shared too. A Behavior shared by several parents is copied once per parent.
+// A Mirror whose target is also in the tree reflects the copy of the target.
+func Clone(b Behavior) Behavior {
+	c := cloneNode(b)
+	clones := make(map[Behavior]Behavior)
+	pairClones(b, c, clones)
+	Walk(c, func(n Behavior) {
+		if m, ok := n.(*mirror); ok && m.target != nil && reflect.TypeOf(m.target).Comparable() {
+			if t, ok := clones[m.target]; ok {
+				m.target = t
+			}
+		}
+	})
+	return c
+}
+
+// cloneNode gets a copy of b, or b itself if it is not a Cloner.
+func cloneNode(b Behavior) Behavior {
+	if c, ok := b.(Cloner); ok {
+		return c.Clone()
+	}
+	return b
+}
+
+// pairClones records the copy of each comparable Behavior in the tree rooted at
+// a, given that the tree rooted at c is its copy.
+func pairClones(a, c Behavior, clones map[Behavior]Behavior) {
+	if a == nil || c == nil {
+		return
+	}
+	if reflect.TypeOf(a).Comparable() {
+		clones[a] = c
+	}
+	ca, cc := children(a), children(c)
+	for i := range min(len(ca), len(cc)) {
+		pairClones(ca[i], cc[i], clones)
+	}
+}
+
+// cloneAll gets a copy of each Behavior.
+func cloneAll(bs []Behavior) []Behavior {
+	if bs == nil {
+		return nil
+	}
+	clones := make([]Behavior, len(bs))
+	for i, b := range bs {
+		clones[i] = cloneNode(b)
+	}
+	return clones
+}
+
+// cloneRand gets a new source of randomness seeded from r, so that a clone
+// does not share r with the original.
+func cloneRand(r *rand.Rand) *rand.Rand {
+	return rand.New(rand.NewSource(r.Int63()))
+}
+
+// clone gets a copy of the composite with copies of each child.
+func (c composite) clone() composite {
+	return composite{cloneAll(c.nodes), c.index, c.state}
+}
+
+// clone gets a copy of the pcomposite with copies of each child.
+func (c pcomposite) clone() pcomposite {
+	complete := make(map[int]bool, len(c.complete))
+	for i, done := range c.complete {
+		complete[i] = done
+	}
+	return pcomposite{cloneAll(c.nodes), complete, c.state}
+}
+
+// clone gets a copy of the wrapper with a copy of the underlying Behavior.
+func (w wrapper) clone() wrapper {
+	return wrapper{cloneNode(w.node), w.state}
+}
+
+// clone gets a copy of the ordered selection with copies of each child.
+func (o ordered) clone() ordered {
+	c := ordered{
+		selection: selection{composite{make([]Behavior, len(o.nodes)), o.index, o.state}},
+		all:       cloneAll(o.all),
+		perm:      append([]int(nil), o.perm...),
+	}
+	c.apply()
+	return c
+}
+
+// Clone gets a deep copy of the sequence.
+func (s *sequence) Clone() Behavior {
+	return &sequence{s.composite.clone()}
+}
+
+// Clone gets a deep copy of the selection.
+func (s *selection) Clone() Behavior {
+	return &selection{s.composite.clone()}
+}
+
+// Clone gets a deep copy of the reactiveSequence.
+func (s *reactiveSequence) Clone() Behavior {
+	return &reactiveSequence{s.composite.clone()}
+}
+
+// Clone gets a deep copy of the reactiveSelection.
+func (s *reactiveSelection) Clone() Behavior {
+	return &reactiveSelection{s.composite.clone()}
+}
+
+// Clone gets a deep copy of the interruptingSelection.
+func (s *interruptingSelection) Clone() Behavior {
+	return &interruptingSelection{reactiveSelection{s.composite.clone()}, s.last}
+}
+
+// Clone gets a deep copy of the switcher.
+func (s *switcher) Clone() Behavior {
+	c := *s
+	c.composite = s.composite.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the atLeast.
+func (a *atLeast) Clone() Behavior {
+	c := *a
+	c.composite = a.composite.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the sequenceAll.
+func (s *sequenceAll) Clone() Behavior {
+	c := *s
+	c.composite = s.composite.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the checkpointSequence.
+func (s *checkpointSequence) Clone() Behavior {
+	return &checkpointSequence{s.composite.clone()}
+}
+
+// Clone gets a deep copy of the checkpoint.
+func (c *checkpoint) Clone() Behavior {
+	return &checkpoint{c.wrapper.clone()}
+}
+
+// Clone gets a deep copy of the randomSelection.
+func (s *randomSelection) Clone() Behavior {
+	return &randomSelection{selection{s.composite.clone()}, cloneRand(s.rng)}
+}
+
+// Clone gets a deep copy of the randomSequence.
+func (s *randomSequence) Clone() Behavior {
+	return &randomSequence{sequence{s.composite.clone()}, cloneRand(s.rng)}
+}
+
+// Clone gets a deep copy of the weightedSelection.
+func (s *weightedSelection) Clone() Behavior {
+	return &weightedSelection{s.ordered.clone(), s.weights, cloneRand(s.rng)}
+}
+
+// Clone gets a deep copy of the prioritySelection.
+func (s *prioritySelection) Clone() Behavior {
+	c := *s
+	c.ordered = s.ordered.clone()
+	c.rng = cloneRand(s.rng)
+	return &c
+}
+
+// Clone gets a deep copy of the utilitySelection.
+func (s *utilitySelection) Clone() Behavior {
+	c := *s
+	c.ordered = s.ordered.clone()
+	c.rng = cloneRand(s.rng)
+	return &c
+}
+
+// Clone gets a deep copy of the utility, sharing the score.
+func (u *utility) Clone() Behavior {
+	return &utility{u.wrapper.clone(), u.score}
+}
+
+// Clone gets a deep copy of the roundRobin.
+func (s *roundRobin) Clone() Behavior {
+	c := *s
+	c.ordered = s.ordered.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the parallel.
+func (p *parallel) Clone() Behavior {
+	c := *p
+	c.pcomposite = p.pcomposite.clone()
+	if p.rng != nil {
+		c.rng = cloneRand(p.rng)
+	}
+	return &c
+}
+
+// Clone gets a deep copy of the debounce.
+func (d *debounce) Clone() Behavior {
+	c := *d
+	c.wrapper = d.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the maxSuccess.
+func (m *maxSuccess) Clone() Behavior {
+	c := *m
+	c.wrapper = m.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the everyN.
+func (e *everyN) Clone() Behavior {
+	c := *e
+	c.wrapper = e.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the parallelN.
+func (p *parallelN) Clone() Behavior {
+	c := *p
+	c.pcomposite = p.pcomposite.clone()
+	return &c
+}
+
+// Clone gets a copy of the streak, sharing the condition.
+func (s *streak) Clone() Behavior {
+	c := *s
+	return &c
+}
+
+// Clone gets a copy of the mirror, which reflects the same target until Clone
+// points it at the copy of the target.
+func (m *mirror) Clone() Behavior {
+	return &mirror{m.target}
+}
+
+// Clone gets a deep copy of the fork.
+func (f *fork) Clone() Behavior {
+	return &fork{f.pcomposite.clone()}
+}
+
+// Clone gets a deep copy of the decorator.
+func (d *decorator) Clone() Behavior {
+	c := *d
+	c.wrapper = d.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the repeatN.
+func (r *repeatN) Clone() Behavior {
+	c := *r
+	c.wrapper = r.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the retryN.
+func (r *retryN) Clone() Behavior {
+	c := *r
+	c.wrapper = r.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the limit.
+func (l *limit) Clone() Behavior {
+	c := *l
+	c.wrapper = l.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the delay.
+func (d *delay) Clone() Behavior {
+	c := *d
+	c.wrapper = d.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the cooldown.
+func (c *cooldown) Clone() Behavior {
+	cc := *c
+	cc.wrapper = c.wrapper.clone()
+	return &cc
+}
+
+// Clone gets a copy of the waitTicks.
+func (w *waitTicks) Clone() Behavior {
+	c := *w
+	return &c
+}
+
+// Clone gets a deep copy of the tagged.
+func (t *tagged) Clone() Behavior {
+	return &tagged{t.wrapper.clone(), t.tags}
+}
+
+// Clone gets a deep copy of the logged, which logs to the same logger.
+func (l *logged) Clone() Behavior {
+	return &logged{l.wrapper.clone(), l.logger, l.prefix}
+}
+
+// Clone gets a deep copy of the named.
+func (n *named) Clone() Behavior {
+	return &named{n.wrapper.clone(), n.name}
+}
+
+// Clone gets a deep copy of the timeout.
+func (t *timeout) Clone() Behavior {
+	c := *t
+	c.wrapper = t.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the timedSequence.
+func (s *timedSequence) Clone() Behavior {
+	c := *s
+	c.composite = s.composite.clone()
+	return &c
+}
+
+// Clone gets a copy of the wait.
+func (w *wait) Clone() Behavior {
+	c := *w
+	return &c
+}
+
+// Clone gets a deep copy of the instrumented, which records into the same
+// NodeStats as the original.
+func (i *instrumented) Clone() Behavior {
+	return &instrumented{i.wrapper.clone(), i.stats}
+}
+
+// Clone gets a deep copy of the counter, which counts into the same
+// CountSnapshot as the original.
+func (c *counter) Clone() Behavior {
+	return &counter{c.wrapper.clone(), c.counts}
+}
+
+// Clone gets a deep copy of the onChange, calling the same function.
+func (o *onChange) Clone() Behavior {
+	c := *o
+	c.wrapper = o.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the circuitBreaker.
+func (c *circuitBreaker) Clone() Behavior {
+	cc := *c
+	cc.wrapper = c.wrapper.clone()
+	return &cc
+}
+
+// Clone gets a deep copy of the traced, which writes into the same buffer as
+// the original.
+func (t *traced) Clone() Behavior {
+	return &traced{t.wrapper.clone(), t.trace}
+}
+
+// Clone gets a deep copy of the synchronized, with its own lock.
+func (s *synchronized) Clone() Behavior {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &synchronized{wrapper: s.wrapper.clone()}
+}
+
+// Clone gets a copy of the receive, which receives from the same channel.
+func (r *receive[T]) Clone() Behavior {
+	return &receive[T]{r.ch, r.handle}
+}
+
+// Clone gets a deep copy of the parallelAsync.
+func (p *parallelAsync) Clone() Behavior {
+	c := *p
+	c.pcomposite = p.pcomposite.clone()
+	return &c
+}
+
+// Clone gets a copy of the actionErr, sharing the underlying function.
+func (a *actionErr) Clone() Behavior {
+	c := *a
+	return &c
+}
+
+// Clone gets a copy of the actionStop, sharing the underlying function but
+// with its own stop channel, which is open.
+func (a *actionStop) Clone() Behavior {
+	return ActionStop(a.fn)
+}
+
+// Clone gets a deep copy of the onUnknown, sharing the function.
+func (o *onUnknown) Clone() Behavior {
+	return &onUnknown{o.wrapper.clone(), o.fn}
+}
+
+// Clone gets a deep copy of the typed.
+func (t *typed[T]) Clone() Behavior {
+	return &typed[T]{t.wrapper.clone()}
+}
+
+// Clone gets a deep copy of the autoReset.
+func (a *autoReset) Clone() Behavior {
+	c := *a
+	c.wrapper = a.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the recovered.
+func (r *recovered) Clone() Behavior {
+	c := *r
+	c.wrapper = r.wrapper.clone()
+	return &c
+}
+
+// Clone gets a copy of the memoized, sharing the Conditional.
+func (m *memoized) Clone() Behavior {
+	c := *m
+	return &c
+}
+
+// Clone gets a copy of the chance.
+func (c *chance) Clone() Behavior {
+	return &chance{c.p, cloneRand(c.rng)}
+}
+
+// Clone gets a deep copy of the once.
+func (o *once) Clone() Behavior {
+	c := *o
+	c.wrapper = o.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the budgetLimit, sharing the clock.
+func (b *budgetLimit) Clone() Behavior {
+	c := *b
+	c.wrapper = b.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the cooldownDuration.
+func (c *cooldownDuration) Clone() Behavior {
+	cc := *c
+	cc.wrapper = c.wrapper.clone()
+	return &cc
+}
+
+// Clone gets a deep copy of the edgeTrigger.
+func (e *edgeTrigger) Clone() Behavior {
+	c := *e
+	c.wrapper = e.wrapper.clone()
+	return &c
+}
+
+// Clone gets a deep copy of the ref, sharing the provider.
+func (r *ref) Clone() Behavior {
+	c := *r
+	if r.node != nil {
+		c.wrapper = r.wrapper.clone()
+	}
+	return &c
+}
+
+// Clone gets a deep copy of the lazy, sharing the provider.
+func (l *lazy) Clone() Behavior {
+	return &lazy{*l.ref.Clone().(*ref)}
+}
+
+// Clone gets a deep copy of the notify, sending on the same channel.
+func (n *notify) Clone() Behavior {
+	return &notify{n.wrapper.clone(), n.ch}
+}
+
+// Clone gets a deep copy of the gate, sharing the enabled flag.
+func (g *gate) Clone() Behavior {
+	return &gate{g.wrapper.clone(), g.enabled, g.running}
+}
+
+// Clone gets a deep copy of the guard, sharing the condition.
+func (g *guard) Clone() Behavior {
+	return &guard{g.wrapper.clone(), g.cond}
+}
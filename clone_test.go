@@ -0,0 +1,49 @@
+package bt
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	original := Sequence(
+		WaitTicks(1),
+		RepeatN(Selection(Recorded(Failure), WaitTicks(1)), 2),
+	)
+	CheckBehavior("Clone (Original)", t, original, []State{Running, Running})
+	clone := Clone(original)
+	CheckBehavior("Clone", t, clone, []State{Running, Running, Success})
+	CheckBehavior("Clone (Original)", t, original, []State{Running, Running, Success})
+	clone.Reset()
+	CheckBehavior("Clone (Reset)", t, clone, []State{Running, Running, Running, Running, Success})
+	CheckBehavior("Clone (Original)", t, original, []State{Success})
+}
+
+func TestClone_Shared(t *testing.T) {
+	calls := 0
+	leaf := Action(func() State {
+		calls++
+		return Success
+	})
+	clone := Clone(Invert(leaf))
+	CheckBehavior("Clone (Shared)", t, clone, []State{Failure})
+	if calls != 1 {
+		t.Error("Clone failed to share Action")
+	}
+}
+
+func TestClone_Mirror(t *testing.T) {
+	cond := Named("cond", Conditional(func() bool { return true }))
+	clone := Clone(Sequence(cond, Mirror(cond)))
+	CheckBehavior("Clone (Mirror)", t, clone, []State{Success})
+}
+
+func TestClone_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	b := RandomSelectionSeeded(r, Recorded(Failure), Recorded(Success))
+	c := Clone(b)
+	CheckBehavior("Clone (Random)", t, c, []State{Success})
+	if c.(*randomSelection).rng == r {
+		t.Error("Clone shared source of randomness")
+	}
+}
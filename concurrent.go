@@ -0,0 +1,261 @@
+package bt
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// synchronized is a Behavior which serializes access to another Behavior.
+type synchronized struct {
+	wrapper
+	mu sync.Mutex
+}
+
+// Synchronized wraps a Behavior so that it may be executed and reset from
+// multiple goroutines. Calls are serialized with a mutex, so only one goroutine
+// runs the tree at a time; nothing is run in parallel.
+func Synchronized(b Behavior) Behavior {
+	return &synchronized{wrapper: wrapper{node: b}}
+}
+
+// Reset resets the underlying Behavior while holding the lock.
+func (s *synchronized) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior while holding the lock.
+func (s *synchronized) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (s *synchronized) ExecuteCtx(ctx context.Context) State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Execute(ctx, s.node)
+}
+
+// State gets the State last returned while holding the lock.
+func (s *synchronized) State() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// setState records the State last returned while holding the lock.
+func (s *synchronized) setState(st State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = st
+}
+
+// parallelAsync is a parallel which runs its child Behavior concurrently.
+type parallelAsync struct {
+	parallel
+	workers int
+}
+
+// ParallelAsync is like Parallel, except that each execution runs the
+// incomplete child Behavior concurrently on up to the given number of
+// goroutines, and waits for them all before applying the policies in child
+// order. The child Behavior must therefore be safe to execute concurrently with
+// each other, as must any tracer set with SetTracer. TraceTree, TracePath and
+// Recorder trace the ParallelAsync itself, but not its children.
+func ParallelAsync(workers int, success, failure Policy, bs ...Behavior) Behavior {
+	return &parallelAsync{
+		parallel: parallel{
+			pcomposite: pcomposite{nodes: bs, complete: make(map[int]bool)},
+			success:    success,
+			failure:    failure,
+		},
+		workers: max(workers, 1),
+	}
+}
+
+// PSequenceAsync is like PSequence, but runs the child Behavior concurrently
+// with one goroutine per CPU. See ParallelAsync.
+func PSequenceAsync(bs ...Behavior) Behavior {
+	return ParallelAsync(runtime.GOMAXPROCS(0), RequireAll, RequireOne, bs...)
+}
+
+// PSelectionAsync is like PSelection, but runs the child Behavior concurrently
+// with one goroutine per CPU. See ParallelAsync.
+func PSelectionAsync(bs ...Behavior) Behavior {
+	return ParallelAsync(runtime.GOMAXPROCS(0), RequireOne, RequireAll, bs...)
+}
+
+// Execute runs each incomplete child Behavior concurrently. It succeeds if the
+// success Policy is met, and fails if the failure Policy is met.
+func (p *parallelAsync) Execute() State {
+	return Execute(context.Background(), p)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (p *parallelAsync) ExecuteCtx(ctx context.Context) State {
+	if s := p.status(); s != Running {
+		return s
+	}
+	var pending []int
+	for i := range p.nodes {
+		if !p.complete[i] {
+			pending = append(pending, i)
+		}
+	}
+	results := make([]State, len(pending))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(p.workers, len(pending)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range jobs {
+				results[k] = Execute(ctx, p.nodes[pending[k]])
+			}
+		}()
+	}
+	for k := range pending {
+		jobs <- k
+	}
+	close(jobs)
+	wg.Wait()
+	for k, i := range pending {
+		switch results[k] {
+		case Success:
+			p.complete[i] = true
+			p.successes++
+		case Failure:
+			p.complete[i] = true
+			p.failures++
+		case Running:
+			continue
+		case Cancelled:
+			return Cancelled
+		default:
+			return Unknown
+		}
+		if s := p.status(); s != Running {
+			return s
+		}
+	}
+	return Running
+}
+
+// notify is a Behavior which reports the results of another Behavior.
+type notify struct {
+	wrapper
+	ch chan<- State
+}
+
+// Notify wraps a Behavior so that each time it succeeds or fails, the result is
+// sent on ch. Sends never block; if ch is not ready, the result is dropped.
+func Notify(b Behavior, ch chan<- State) Behavior {
+	return &notify{wrapper{node: b}, ch}
+}
+
+// Execute runs the underlying Behavior, sending and returning the result.
+func (n *notify) Execute() State {
+	return Execute(context.Background(), n)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (n *notify) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, n.node)
+	if s == Success || s == Failure {
+		select {
+		case n.ch <- s:
+		default:
+		}
+	}
+	return s
+}
+
+// actionStop is a Behavior which runs a function which can be told to stop.
+type actionStop struct {
+	fn      func(stop <-chan struct{}) State
+	mu      sync.Mutex
+	stop    chan struct{}
+	stopped bool
+}
+
+// ActionStop gets a Behavior which runs fn, passing a channel which is closed
+// once the Behavior is stopped, so that a long or blocking operation can bail
+// out early. The Behavior is stopped by calling its Stop method, which may be
+// done from another goroutine, or by Abort, such as when it is preempted. It
+// is up to fn to observe the channel and return, typically with Failure. The
+// channel stays closed until Reset.
+func ActionStop(fn func(stop <-chan struct{}) State) Behavior {
+	return &actionStop{fn: fn, stop: make(chan struct{})}
+}
+
+// Reset replaces the stop channel if it was closed.
+func (a *actionStop) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopped {
+		a.stop = make(chan struct{})
+		a.stopped = false
+	}
+}
+
+// Execute runs the function and returns the result.
+func (a *actionStop) Execute() State {
+	a.mu.Lock()
+	stop := a.stop
+	a.mu.Unlock()
+	return a.fn(stop)
+}
+
+// Stop closes the stop channel, if it is not already closed. It is safe to
+// call from any goroutine.
+func (a *actionStop) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.stopped {
+		close(a.stop)
+		a.stopped = true
+	}
+}
+
+// Abort stops the function.
+func (a *actionStop) Abort() {
+	a.Stop()
+}
+
+// receive is a Behavior which handles values received from a channel.
+type receive[T any] struct {
+	ch     <-chan T
+	handle func(T) State
+}
+
+// Receive gets a Behavior which, on every execution, receives from ch without
+// blocking. If a value is ready, handle is called with it and the result
+// returned. Otherwise, it is Running, waiting for a value to arrive. Once ch is
+// closed, it fails. Each execution receives at most one value.
+func Receive[T any](ch <-chan T, handle func(T) State) Behavior {
+	return &receive[T]{ch, handle}
+}
+
+// Name gets the name of the receive.
+func (r *receive[T]) Name() string {
+	return "Receive"
+}
+
+// Reset is a noop.
+func (r *receive[T]) Reset() {}
+
+// Execute handles a value from the channel if one is ready, is Running if not,
+// and fails if the channel is closed.
+func (r *receive[T]) Execute() State {
+	select {
+	case v, ok := <-r.ch:
+		if !ok {
+			return Failure
+		}
+		return r.handle(v)
+	default:
+		return Running
+	}
+}
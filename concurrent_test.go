@@ -0,0 +1,212 @@
+package bt
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSynchronized(t *testing.T) {
+	count := 0
+	b := Synchronized(Repeat(Sequence(
+		Func(func() { count++ }),
+		WaitTicks(1),
+	)))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if actual := b.Execute(); actual != Running {
+					t.Error("Synchronized produced incorrect state:", actual)
+				}
+				if j%10 == 0 {
+					b.Reset()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if count == 0 {
+		t.Error("Synchronized failed to execute wrapped Behavior")
+	}
+}
+
+func TestSynchronized_State(t *testing.T) {
+	b := Synchronized(Recorded(Running, Success))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			b.Execute()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if s := b.(Stateful).State(); s == Failure {
+			t.Error("Synchronized recorded incorrect state:", s)
+		}
+	}
+	<-done
+	if s := b.(Stateful).State(); s != Success {
+		t.Error("Synchronized recorded incorrect state:", s)
+	}
+}
+
+// barrier gets n Behavior which each succeed only if all n are executed
+// concurrently, and fail otherwise.
+func barrier(n int) []Behavior {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	bs := make([]Behavior, n)
+	for i := range bs {
+		bs[i] = Action(func() State {
+			wg.Done()
+			select {
+			case <-done:
+				return Success
+			case <-time.After(time.Second):
+				return Failure
+			}
+		})
+	}
+	return bs
+}
+
+func TestPSequenceAsync(t *testing.T) {
+	b := ParallelAsync(4, RequireAll, RequireOne, barrier(4)...)
+	CheckBehavior("PSequenceAsync", t, b, []State{Success})
+}
+
+func TestPSequenceAsync_Trace(t *testing.T) {
+	root, buf := TraceTree(Sequence(ParallelAsync(4, RequireAll, RequireOne, barrier(4)...)))
+	CheckBehavior("PSequenceAsync (Trace)", t, root, []State{Success})
+	expected := "Sequence: Success\n" +
+		"  ParallelAsync: Success\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("TraceTree produced incorrect trace:\n%s", actual)
+	}
+}
+
+func TestPSequenceAsync_Failure(t *testing.T) {
+	child := &testBehavior{base: Recorded(Running, Running, Success)}
+	b := PSequenceAsync(
+		child,
+		Recorded(Running, Failure),
+		Recorded(Success),
+	)
+	expected := []State{Running, Failure}
+	CheckBehavior("PSequenceAsync (Failure)", t, b, expected)
+	if child.calls != 2 {
+		t.Error("PSequenceAsync (Failure) failed to call child each Execute")
+	}
+}
+
+func TestPSelectionAsync(t *testing.T) {
+	b := PSelectionAsync(
+		Recorded(Running, Running, Failure),
+		Recorded(Failure),
+		Recorded(Running, Success),
+	)
+	expected := []State{Running, Success}
+	CheckBehavior("PSelectionAsync", t, b, expected)
+	b = PSelectionAsync(Recorded(Running, Failure), Recorded(Failure))
+	CheckBehavior("PSelectionAsync (Failure)", t, b, []State{Running, Failure})
+}
+
+func TestPSelectionAsync_Unknown(t *testing.T) {
+	b := PSelectionAsync(Recorded(Running), Recorded(Running, Unknown))
+	CheckBehavior("PSelectionAsync (Unknown)", t, b, []State{Running, Unknown})
+}
+
+func TestNotify(t *testing.T) {
+	ch := make(chan State, 10)
+	b := Notify(Recorded(Running, Success, Running, Failure, Unknown), ch)
+	CheckBehavior("Notify", t, b, []State{Running, Success, Running, Failure, Unknown})
+	close(ch)
+	var actual []State
+	for s := range ch {
+		actual = append(actual, s)
+	}
+	if !reflect.DeepEqual([]State{Success, Failure}, actual) {
+		t.Error("Notify sent incorrect states:", actual)
+	}
+}
+
+func TestNotify_Full(t *testing.T) {
+	ch := make(chan State)
+	b := Notify(Recorded(Success), ch)
+	CheckBehavior("Notify (Full)", t, b, []State{Success, Success})
+}
+
+func TestReceive(t *testing.T) {
+	ch := make(chan int, 10)
+	var got []int
+	b := Receive(ch, func(v int) State {
+		got = append(got, v)
+		if v < 0 {
+			return Failure
+		}
+		return Success
+	})
+	CheckBehavior("Receive (Empty)", t, b, []State{Running})
+	ch <- 1
+	ch <- -2
+	CheckBehavior("Receive", t, b, []State{Success, Failure, Running})
+	ch <- 3
+	close(ch)
+	CheckBehavior("Receive (Closed)", t, b, []State{Success, Failure, Failure})
+	if !reflect.DeepEqual([]int{1, -2, 3}, got) {
+		t.Error("Receive handled incorrect values:", got)
+	}
+	if name := NameOf(b); name != "Receive" {
+		t.Error("Receive produced incorrect name:", name)
+	}
+}
+
+func TestActionStop(t *testing.T) {
+	started := make(chan struct{})
+	b := ActionStop(func(stop <-chan struct{}) State {
+		close(started)
+		select {
+		case <-stop:
+			return Failure
+		case <-time.After(time.Second):
+			return Success
+		}
+	})
+	go func() {
+		<-started
+		b.(interface{ Stop() }).Stop()
+	}()
+	begin := time.Now()
+	if actual := b.Execute(); actual != Failure {
+		t.Error("ActionStop produced incorrect state:", actual)
+	}
+	if time.Since(begin) >= time.Second {
+		t.Error("ActionStop failed to stop promptly")
+	}
+}
+
+func TestActionStop_Abort(t *testing.T) {
+	b := ActionStop(func(stop <-chan struct{}) State {
+		select {
+		case <-stop:
+			return Failure
+		default:
+			return Running
+		}
+	})
+	CheckBehavior("ActionStop", t, b, []State{Running})
+	Abort(b)
+	Abort(b)
+	CheckBehavior("ActionStop (Abort)", t, b, []State{Failure, Failure})
+	b.Reset()
+	CheckBehavior("ActionStop (Reset)", t, b, []State{Running})
+}
@@ -0,0 +1,84 @@
+package bt
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Equal reports whether the trees rooted at a and b have the same structure,
+// as described by Diff.
+func Equal(a, b Behavior) bool {
+	return Diff(a, b) == ""
+}
+
+// Diff describes the first structural mismatch between the trees rooted at a
+// and b, prefixed with its path, or gets the empty string if there is none.
+// Parameters such as a cooldown are compared, but random sources and clocks
+// are not.
+func Diff(a, b Behavior) string {
+	var visit func(a, b Behavior, path string) string
+	visit = func(a, b Behavior, path string) string {
+		if a == nil || b == nil {
+			if a == nil && b == nil {
+				return ""
+			}
+			return fmt.Sprintf("%s: %s != %s", path, label(a), label(b))
+		}
+		if reflect.TypeOf(a) != reflect.TypeOf(b) {
+			return fmt.Sprintf("%s: %T != %T", path, a, b)
+		}
+		if la, lb := label(a), label(b); la != lb {
+			return fmt.Sprintf("%s: %s != %s", path, la, lb)
+		}
+		ca, cb := children(a), children(b)
+		if len(ca) != len(cb) {
+			return fmt.Sprintf("%s: %d children != %d children", path, len(ca), len(cb))
+		}
+		for i := range ca {
+			if d := visit(ca[i], cb[i], fmt.Sprintf("%s/%s[%d]", path, nameOrNil(ca[i]), i)); d != "" {
+				return d
+			}
+		}
+		return ""
+	}
+	return visit(a, b, nameOrNil(a))
+}
+
+// nameOrNil gets the NameOf b, or nil if b is nil.
+func nameOrNil(b Behavior) string {
+	if b == nil {
+		return "nil"
+	}
+	return NameOf(b)
+}
+
+// label gets the NameOf b along with its parameters, if any.
+func label(b Behavior) string {
+	if b == nil {
+		return "nil"
+	}
+	d := describeNode(b)
+	if d.Params == nil {
+		return d.Type
+	}
+	keys := make([]string, 0, len(d.Params))
+	for k := range d.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, len(keys))
+	for i, k := range keys {
+		params[i] = fmt.Sprintf("%s=%v", k, d.Params[k])
+	}
+	return fmt.Sprintf("%s(%s)", d.Type, strings.Join(params, ", "))
+}
+
+// children gets the children of b, if it is a Composite.
+func children(b Behavior) []Behavior {
+	if c, ok := b.(Composite); ok {
+		return c.Children()
+	}
+	return nil
+}
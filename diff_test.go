@@ -0,0 +1,47 @@
+package bt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEqual(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterLeaf("Idle", Action(func() State { return Running }))
+	data := `{"type":"Selection","children":[` +
+		`{"type":"RepeatN","params":{"n":2},"children":[{"type":"Idle"}]},` +
+		`{"type":"PSequence","children":[{"type":"Idle"}]}]}`
+	b, err := UnmarshalJSON([]byte(data), reg)
+	if err != nil {
+		t.Fatal("UnmarshalJSON produced unexpected error:", err)
+	}
+	idle := Named("Idle", Action(nil))
+	expected := Selection(RepeatN(idle, 2), PSequence(idle))
+	if !Equal(expected, b) {
+		t.Error("Equal failed to match identical trees:", Diff(expected, b))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	leaf := Action(nil)
+	cases := []struct {
+		a, b     Behavior
+		expected string
+	}{
+		{Sequence(leaf), Selection(leaf), "Sequence: *bt.sequence != *bt.selection"},
+		{Invert(leaf), Repeat(leaf), "Invert: Invert != Repeat"},
+		{RepeatN(leaf, 2), RepeatN(leaf, 3), "RepeatN: RepeatN(n=2) != RepeatN(n=3)"},
+		{
+			CircuitBreakerCooldown(leaf, 2, time.Second), CircuitBreakerCooldown(leaf, 2, time.Minute),
+			"CircuitBreaker: CircuitBreaker(cooldown=1s, threshold=2) != CircuitBreaker(cooldown=1m0s, threshold=2)",
+		},
+		{Sequence(leaf), Sequence(leaf, leaf), "Sequence: 1 children != 2 children"},
+		{Sequence(Selection(leaf, nil)), Sequence(Selection(leaf, leaf)), "Sequence/Selection[0]/nil[1]: nil != Action"},
+		{nil, nil, ""},
+	}
+	for _, c := range cases {
+		if actual := Diff(c.a, c.b); actual != c.expected {
+			t.Errorf("Diff produced incorrect mismatch: %q", actual)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package bt
+
+import (
+	"context"
+	"fmt"
+)
+
+// Errorer is a Behavior which can report the error behind its last result.
+type Errorer interface {
+	Err() error
+}
+
+// Err gets the error behind the last result of b if it is an Errorer, or nil
+// otherwise.
+func Err(b Behavior) error {
+	if e, ok := b.(Errorer); ok {
+		return e.Err()
+	}
+	return nil
+}
+
+// Err gets the error from the child which was last executed.
+func (c *composite) Err() error {
+	if len(c.nodes) == 0 {
+		return nil
+	}
+	return Err(c.nodes[min(c.index, len(c.nodes)-1)])
+}
+
+// Err gets the first error from the child Behavior.
+func (c *pcomposite) Err() error {
+	for _, n := range c.nodes {
+		if err := Err(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Err gets the error from the underlying Behavior.
+func (w *wrapper) Err() error {
+	return Err(w.node)
+}
+
+// actionErr is a Behavior which runs a function which may return an error.
+type actionErr struct {
+	fn  func() (State, error)
+	err error
+}
+
+// ActionErr gets a Behavior which calls fn and returns the resulting State,
+// except that it fails if fn returns an error. The error is available from Err
+// until the next execution or Reset.
+func ActionErr(fn func() (State, error)) Behavior {
+	return &actionErr{fn: fn}
+}
+
+// Reset clears the error.
+func (a *actionErr) Reset() {
+	a.err = nil
+}
+
+// Execute calls the underlying function and returns the result, or Failure if
+// the function returned an error.
+func (a *actionErr) Execute() State {
+	var s State
+	s, a.err = a.fn()
+	if a.err != nil {
+		return Failure
+	}
+	return s
+}
+
+// Err gets the error returned by the last execution.
+func (a *actionErr) Err() error {
+	return a.err
+}
+
+// recovered is a Behavior which recovers panics from another Behavior.
+type recovered struct {
+	wrapper
+	err error
+}
+
+// Recover wraps a Behavior so that a panic while it executes results in
+// Failure instead, with the recovered value available from Err. Panics during
+// Reset are also recovered and recorded.
+func Recover(b Behavior) Behavior {
+	return &recovered{wrapper: wrapper{node: b}}
+}
+
+// Reset resets the underlying Behavior, recovering from any panic.
+func (r *recovered) Reset() {
+	r.err = nil
+	defer func() {
+		if v := recover(); v != nil {
+			r.record(v)
+		}
+	}()
+	r.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior and returns the result, or Failure if it
+// panics.
+func (r *recovered) Execute() State {
+	return Execute(context.Background(), r)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (r *recovered) ExecuteCtx(ctx context.Context) (s State) {
+	r.err = nil
+	defer func() {
+		if v := recover(); v != nil {
+			r.record(v)
+			s = Failure
+		}
+	}()
+	return Execute(ctx, r.node)
+}
+
+// record records the value recovered from a panic as an error.
+func (r *recovered) record(v any) {
+	if err, ok := v.(error); ok {
+		r.err = fmt.Errorf("bt: recovered panic: %w", err)
+	} else {
+		r.err = fmt.Errorf("bt: recovered panic: %v", v)
+	}
+}
+
+// Err gets the error recovered from the last panic, or else the error from the
+// underlying Behavior.
+func (r *recovered) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return Err(r.node)
+}
+
+// onUnknown is a Behavior which substitutes another State for Unknown.
+type onUnknown struct {
+	wrapper
+	fn func() State
+}
+
+// OnUnknown wraps a Behavior so that whenever it returns Unknown, which usually
+// means something went wrong, fn is called and its result returned instead. If
+// fn is nil, Failure is returned instead. This lets the degenerate case be
+// handled in one place rather than leaking up through every composite.
+func OnUnknown(b Behavior, fn func() State) Behavior {
+	return &onUnknown{wrapper{node: b}, fn}
+}
+
+// MapUnknown wraps a Behavior so that whenever it returns Unknown, to is
+// returned instead, and every other State passes through. It is a shorthand for
+// OnUnknown with a constant substitute, and panics if to is neither Success nor
+// Failure.
+func MapUnknown(b Behavior, to State) Behavior {
+	if to != Success && to != Failure {
+		panic(fmt.Sprintf("bt: MapUnknown requires Success or Failure, not %v", to))
+	}
+	return OnUnknown(b, func() State { return to })
+}
+
+// Execute runs the underlying Behavior and returns the result, substituting for
+// Unknown.
+func (o *onUnknown) Execute() State {
+	return Execute(context.Background(), o)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (o *onUnknown) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, o.node)
+	if s != Unknown {
+		return s
+	}
+	if o.fn == nil {
+		return Failure
+	}
+	return o.fn()
+}
@@ -0,0 +1,104 @@
+package bt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestActionErr(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	results := []error{nil, errFlaky}
+	i := 0
+	b := ActionErr(func() (State, error) {
+		err := results[i%len(results)]
+		i++
+		return Success, err
+	})
+	CheckBehavior("ActionErr", t, b, []State{Success, Failure})
+	if err := Err(b); err != errFlaky {
+		t.Error("ActionErr produced incorrect error:", err)
+	}
+	b.Reset()
+	if err := Err(b); err != nil {
+		t.Error("ActionErr failed to clear error on Reset:", err)
+	}
+}
+
+func TestErr_Composite(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	fail := func(err error) Behavior {
+		return ActionErr(func() (State, error) { return Failure, err })
+	}
+	b := Invert(Selection(
+		fail(errFirst),
+		Sequence(Recorded(Success), fail(errSecond), fail(nil)),
+	))
+	CheckBehavior("Err (Composite)", t, b, []State{Success})
+	if err := b.(Errorer).Err(); err != errSecond {
+		t.Error("Err (Composite) produced incorrect error:", err)
+	}
+	p := PSequence(Recorded(Running), fail(errFirst))
+	CheckBehavior("Err (Parallel)", t, p, []State{Failure})
+	if err := Err(p); err != errFirst {
+		t.Error("Err (Parallel) produced incorrect error:", err)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	b := Recover(Sequence(
+		Recorded(Success),
+		Action(func() State { panic("boom") }),
+	))
+	CheckBehavior("Recover", t, b, []State{Failure})
+	if err := Err(b); err == nil || err.Error() != "bt: recovered panic: boom" {
+		t.Error("Recover produced incorrect error:", err)
+	}
+}
+
+func TestRecover_Reset(t *testing.T) {
+	errBoom := errors.New("boom")
+	b := Recover(&testBehavior{})
+	b.Reset()
+	if err := Err(b); err == nil {
+		t.Error("Recover failed to recover panic in Reset")
+	}
+	b = Recover(Action(func() State { panic(errBoom) }))
+	CheckBehavior("Recover (Error)", t, b, []State{Failure})
+	if err := Err(b); !errors.Is(err, errBoom) {
+		t.Error("Recover failed to wrap panicked error:", err)
+	}
+}
+
+func TestOnUnknown(t *testing.T) {
+	calls := 0
+	b := OnUnknown(Recorded(Running, Unknown, Success), func() State {
+		calls++
+		return Running
+	})
+	CheckBehavior("OnUnknown", t, b, []State{Running, Running, Success})
+	if calls != 1 {
+		t.Error("OnUnknown called substitute incorrectly", calls)
+	}
+	b = OnUnknown(Sequence(Recorded(Success), Recorded(Unknown)), nil)
+	CheckBehavior("OnUnknown (Default)", t, b, []State{Failure})
+}
+
+func TestMapUnknown(t *testing.T) {
+	states := []State{Unknown, Running, Success, Failure, Cancelled}
+	for _, to := range []State{Success, Failure} {
+		b := MapUnknown(Recorded(states...), to)
+		expected := []State{to, Running, Success, Failure, Cancelled}
+		CheckBehavior("MapUnknown", t, b, expected)
+	}
+	for _, to := range []State{Unknown, Running, Cancelled} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("MapUnknown failed to panic for non-terminal state:", to)
+				}
+			}()
+			MapUnknown(Recorded(Success), to)
+		}()
+	}
+}
@@ -0,0 +1,121 @@
+package bt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// graph walks the tree rooted at root, calling node for each Behavior and edge
+// for each parent and child pair. A Behavior shared by several parents is only
+// given one node, so long as its type is comparable.
+func graph(root Behavior, node func(id int, b Behavior, leaf bool), edge func(parent, child int)) error {
+	ids := make(map[Behavior]int)
+	next := 0
+	var visit func(b Behavior) (int, error)
+	visit = func(b Behavior) (int, error) {
+		if b == nil {
+			return 0, errors.New("bt: nil Behavior in tree")
+		}
+		comparable := reflect.TypeOf(b).Comparable()
+		if comparable {
+			if n, ok := ids[b]; ok {
+				return n, nil
+			}
+		}
+		n := next
+		next++
+		if comparable {
+			ids[b] = n
+		}
+		c, ok := b.(Composite)
+		node(n, b, !ok)
+		if !ok {
+			return n, nil
+		}
+		for _, child := range c.Children() {
+			m, err := visit(child)
+			if err != nil {
+				return 0, err
+			}
+			edge(n, m)
+		}
+		return n, nil
+	}
+	_, err := visit(root)
+	return err
+}
+
+// ToDOT gets a Graphviz DOT graph of the tree rooted at root.
+func ToDOT(root Behavior) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("digraph {\n")
+	err := graph(root,
+		func(id int, b Behavior, leaf bool) {
+			if leaf {
+				fmt.Fprintf(&sb, "\tn%d [label=%q, shape=box];\n", id, NameOf(b))
+			} else {
+				fmt.Fprintf(&sb, "\tn%d [label=%q];\n", id, NameOf(b))
+			}
+		},
+		func(parent, child int) {
+			fmt.Fprintf(&sb, "\tn%d -> n%d;\n", parent, child)
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+// ToMermaid gets a Mermaid flowchart of the tree rooted at root.
+func ToMermaid(root Behavior) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("graph TD\n")
+	err := graph(root,
+		func(id int, b Behavior, leaf bool) {
+			label := strings.ReplaceAll(NameOf(b), `"`, "#quot;")
+			if leaf {
+				fmt.Fprintf(&sb, "\tn%d[\"%s\"]\n", id, label)
+			} else {
+				fmt.Fprintf(&sb, "\tn%d(\"%s\")\n", id, label)
+			}
+		},
+		func(parent, child int) {
+			fmt.Fprintf(&sb, "\tn%d --> n%d\n", parent, child)
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// String gets an indented outline of the tree rooted at root, one NameOf per
+// line.
+func String(root Behavior) string {
+	var sb strings.Builder
+	var visit func(b Behavior, depth int)
+	visit = func(b Behavior, depth int) {
+		for i := 0; i < depth; i++ {
+			sb.WriteString("  ")
+		}
+		if b == nil {
+			sb.WriteString("nil\n")
+			return
+		}
+		sb.WriteString(NameOf(b))
+		sb.WriteByte('\n')
+		c, ok := b.(Composite)
+		if !ok {
+			return
+		}
+		for _, child := range c.Children() {
+			visit(child, depth+1)
+		}
+	}
+	visit(root, 0)
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package bt
+
+import "testing"
+
+func TestToDOT(t *testing.T) {
+	b := Sequence(
+		Named("ready", Conditional(func() bool { return true })),
+		Invert(Action(func() State { return Failure })),
+	)
+	actual, err := ToDOT(b)
+	if err != nil {
+		t.Fatal("ToDOT produced unexpected error:", err)
+	}
+	expected := `digraph {
+	n0 [label="Sequence"];
+	n1 [label="ready"];
+	n2 [label="Conditional", shape=box];
+	n1 -> n2;
+	n0 -> n1;
+	n3 [label="Invert"];
+	n4 [label="Action", shape=box];
+	n3 -> n4;
+	n0 -> n3;
+}
+`
+	if actual != expected {
+		t.Error("ToDOT produced incorrect output:", actual)
+	}
+}
+
+func TestToDOT_Nil(t *testing.T) {
+	if _, err := ToDOT(Sequence(nil)); err == nil {
+		t.Error("ToDOT failed to report nil Behavior")
+	}
+}
+
+func TestToMermaid(t *testing.T) {
+	shared := Named(`"shared"`, Action(func() State { return Success }))
+	b := Selection(shared, Sequence(shared, Func(func() {})))
+	actual, err := ToMermaid(b)
+	if err != nil {
+		t.Fatal("ToMermaid produced unexpected error:", err)
+	}
+	expected := `graph TD
+	n0("Selection")
+	n1("#quot;shared#quot;")
+	n2["Action"]
+	n1 --> n2
+	n0 --> n1
+	n3("Sequence")
+	n3 --> n1
+	n4["Func"]
+	n3 --> n4
+	n0 --> n3
+`
+	if actual != expected {
+		t.Error("ToMermaid produced incorrect output:", actual)
+	}
+}
+
+func TestToMermaid_Nil(t *testing.T) {
+	if _, err := ToMermaid(Invert(nil)); err == nil {
+		t.Error("ToMermaid failed to report nil Behavior")
+	}
+}
+
+func TestString(t *testing.T) {
+	b := Named("root", Sequence(
+		Conditional(func() bool { return true }),
+		Selection(Action(nil), nil),
+	))
+	expected := "root\n" +
+		"  Sequence\n" +
+		"    Conditional\n" +
+		"    Selection\n" +
+		"      Action\n" +
+		"      nil\n"
+	if actual := String(b); actual != expected {
+		t.Errorf("String produced incorrect tree:\n%s", actual)
+	}
+}
@@ -0,0 +1,64 @@
+package bt
+
+// Interruptible is a Behavior which can be told to stop while it is Running.
+//
+// Abort and Reset differ in intent. Reset restarts a Behavior, so that its next
+// execution begins a new activation, and is called whether or not it is
+// Running. Abort tears down a Running Behavior, such as by releasing resources
+// held by a long-running action, and is only needed when the Behavior is being
+// stopped before it completes. Abort does not itself restart anything, so a
+// Behavior is always reset after it is aborted by a composite or decorator.
+type Interruptible interface {
+	Abort()
+}
+
+// Abort tells b to stop if it is Interruptible, and otherwise does nothing.
+// Composites and decorators are Interruptible, and forward Abort to whichever
+// children are Running, so aborting the root of a tree reaches every Running
+// leaf. A child is considered Running if it last returned Running, or
+// Cancelled, since a cancelled subtree keeps its progress, or if it is not
+// Stateful and so cannot tell.
+func Abort(b Behavior) {
+	if i, ok := b.(Interruptible); ok {
+		i.Abort()
+	}
+}
+
+// running reports whether b may be Running.
+func running(b Behavior) bool {
+	if s, ok := b.(Stateful); ok {
+		return s.State() == Running || s.State() == Cancelled
+	}
+	return true
+}
+
+// interrupt aborts b if it may be Running, and then resets it.
+func interrupt(b Behavior) {
+	if running(b) {
+		Abort(b)
+	}
+	b.Reset()
+}
+
+// Abort aborts the current child if it is Running.
+func (c *composite) Abort() {
+	if c.index >= 0 && c.index < len(c.nodes) && running(c.nodes[c.index]) {
+		Abort(c.nodes[c.index])
+	}
+}
+
+// Abort aborts each incomplete child which is Running.
+func (c *pcomposite) Abort() {
+	for i, n := range c.nodes {
+		if !c.complete[i] && running(n) {
+			Abort(n)
+		}
+	}
+}
+
+// Abort aborts the underlying Behavior if it is Running.
+func (w *wrapper) Abort() {
+	if w.node != nil && running(w.node) {
+		Abort(w.node)
+	}
+}
@@ -0,0 +1,86 @@
+package bt
+
+import (
+	"testing"
+)
+
+type abortBehavior struct {
+	testBehavior
+	aborts int
+}
+
+func (b *abortBehavior) Abort() {
+	b.aborts++
+}
+
+func TestAbort_Sequence(t *testing.T) {
+	done := &abortBehavior{testBehavior: testBehavior{base: Recorded(Success)}}
+	active := &abortBehavior{testBehavior: testBehavior{base: Recorded(Running)}}
+	b := Sequence(Named("done", done), Named("active", active))
+	CheckBehavior("Sequence", t, b, []State{Running})
+	Abort(b)
+	if done.aborts != 0 || active.aborts != 1 {
+		t.Error("Abort failed to forward to only the Running child", done.aborts, active.aborts)
+	}
+	if active.resets != 0 {
+		t.Error("Abort reset child")
+	}
+}
+
+func TestAbort_PSequence(t *testing.T) {
+	children := []*abortBehavior{
+		{testBehavior: testBehavior{base: Recorded(Running)}},
+		{testBehavior: testBehavior{base: Recorded(Success)}},
+		{testBehavior: testBehavior{base: Recorded(Running)}},
+	}
+	b := Invert(PSequence(children[0], children[1], children[2]))
+	CheckBehavior("PSequence", t, b, []State{Running})
+	Abort(b)
+	for i, expected := range []int{1, 0, 1} {
+		if children[i].aborts != expected {
+			t.Errorf("Abort aborted child %d %d times", i, children[i].aborts)
+		}
+	}
+}
+
+func TestAbort_Preempt(t *testing.T) {
+	high := Recorded(Failure, Success)
+	low := &abortBehavior{testBehavior: testBehavior{base: Recorded(Running)}}
+	b := ReactiveSelection(high, low)
+	CheckBehavior("ReactiveSelection", t, b, []State{Running, Success})
+	if low.aborts != 1 || low.resets != 1 {
+		t.Error("ReactiveSelection failed to abort and reset preempted child", low.aborts, low.resets)
+	}
+}
+
+func TestAbort_Guard(t *testing.T) {
+	open := true
+	body := &abortBehavior{testBehavior: testBehavior{base: Recorded(Running)}}
+	b := Guard(func() bool { return open }, Named("body", body))
+	CheckBehavior("Guard", t, b, []State{Running})
+	open = false
+	CheckBehavior("Guard", t, b, []State{Failure, Failure})
+	if body.aborts != 1 || body.resets != 2 {
+		t.Error("Guard failed to abort Running body once", body.aborts, body.resets)
+	}
+}
+
+func TestInterruptingSelection(t *testing.T) {
+	threat := false
+	flee := &abortBehavior{testBehavior: testBehavior{base: Recorded(Running)}}
+	high := &testBehavior{base: Sequence(Conditional(func() bool { return threat }), Named("flee", flee))}
+	wander := &abortBehavior{testBehavior: testBehavior{base: Recorded(Running)}}
+	b := InterruptingSelection(high, Named("wander", wander))
+	CheckBehavior("InterruptingSelection", t, b, []State{Running, Running})
+	if high.resets != 1 {
+		t.Error("InterruptingSelection failed to reset failed child", high.resets)
+	}
+	threat = true
+	CheckBehavior("InterruptingSelection", t, b, []State{Running, Running})
+	if wander.aborts != 1 || wander.resets != 1 {
+		t.Error("InterruptingSelection failed to tear down lower child", wander.aborts, wander.resets)
+	}
+	if high.resets != 2 || flee.calls != 2 {
+		t.Error("InterruptingSelection reset Running child", high.resets, flee.calls)
+	}
+}
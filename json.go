@@ -0,0 +1,334 @@
+package bt
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Factory builds a Behavior from its parameters and its children.
+type Factory func(params map[string]any, children []Behavior) (Behavior, error)
+
+// Registry maps the type names used in JSON tree definitions to Factory.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry gets a Registry with the composites and decorators of this
+// package already registered under the names of their constructors.
+func NewRegistry() *Registry {
+	r := &Registry{make(map[string]Factory)}
+	composites := map[string]func(...Behavior) Behavior{
+		"Sequence":              Sequence,
+		"Selection":             Selection,
+		"ReactiveSequence":      ReactiveSequence,
+		"ReactiveSelection":     ReactiveSelection,
+		"PSequence":             PSequence,
+		"PSelection":            PSelection,
+		"PSequenceResetOnFail":  PSequenceResetOnFail,
+		"SequenceAll":           SequenceAll,
+		"InterruptingSelection": InterruptingSelection,
+		"RoundRobin":            RoundRobin,
+		"Race":                  Race,
+		"CheckpointSequence":    CheckpointSequence,
+	}
+	for typ, fn := range composites {
+		r.Register(typ, func(_ map[string]any, children []Behavior) (Behavior, error) {
+			return fn(children...), nil
+		})
+	}
+	decorators := map[string]func(Behavior) Behavior{
+		"Invert":        Invert,
+		"Repeat":        Repeat,
+		"ForceSuccess":  ForceSuccess,
+		"ForceFailure":  ForceFailure,
+		"Until":         Until,
+		"While":         While,
+		"AlwaysRunning": AlwaysRunning,
+		"AutoReset":     AutoReset,
+		"Once":          Once,
+		"EdgeTrigger":   EdgeTrigger,
+		"Checkpoint":    Checkpoint,
+	}
+	for typ, fn := range decorators {
+		r.Register(typ, func(_ map[string]any, children []Behavior) (Behavior, error) {
+			if len(children) != 1 {
+				return nil, fmt.Errorf("bt: %s requires exactly one child", typ)
+			}
+			return fn(children[0]), nil
+		})
+	}
+	counted := map[string]struct {
+		param string
+		fn    func(Behavior, int) Behavior
+	}{
+		"RepeatN":    {"n", RepeatN},
+		"RetryN":     {"n", RetryN},
+		"Limit":      {"max", Limit},
+		"Delay":      {"ticks", Delay},
+		"Cooldown":   {"ticks", Cooldown},
+		"Debounce":   {"ticks", Debounce},
+		"EveryN":     {"n", EveryN},
+		"MaxSuccess": {"n", MaxSuccess},
+	}
+	for typ, c := range counted {
+		r.Register(typ, func(params map[string]any, children []Behavior) (Behavior, error) {
+			if len(children) != 1 {
+				return nil, fmt.Errorf("bt: %s requires exactly one child", typ)
+			}
+			n, err := intParam(params, typ, c.param)
+			if err != nil {
+				return nil, err
+			}
+			return c.fn(children[0], n), nil
+		})
+	}
+	r.Register("CircuitBreaker", func(params map[string]any, children []Behavior) (Behavior, error) {
+		if len(children) != 1 {
+			return nil, fmt.Errorf("bt: CircuitBreaker requires exactly one child")
+		}
+		n, err := intParam(params, "CircuitBreaker", "threshold")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := params["cooldown"]; !ok {
+			return CircuitBreaker(children[0], n), nil
+		}
+		s, _ := params["cooldown"].(string)
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("bt: CircuitBreaker requires duration parameter \"cooldown\"")
+		}
+		return CircuitBreakerCooldown(children[0], n, d), nil
+	})
+	r.Register("ParallelN", func(params map[string]any, children []Behavior) (Behavior, error) {
+		n, err := intParam(params, "ParallelN", "threshold")
+		if err != nil {
+			return nil, err
+		}
+		return ParallelN(n, children...), nil
+	})
+	r.Register("PSequenceBatched", func(params map[string]any, children []Behavior) (Behavior, error) {
+		n, err := intParam(params, "PSequenceBatched", "batch")
+		if err != nil {
+			return nil, err
+		}
+		return PSequenceBatched(n, children...), nil
+	})
+	r.Register("AtLeast", func(params map[string]any, children []Behavior) (Behavior, error) {
+		n, err := intParam(params, "AtLeast", "n")
+		if err != nil {
+			return nil, err
+		}
+		return AtLeast(n, children...), nil
+	})
+	r.Register("Parallel", func(params map[string]any, children []Behavior) (Behavior, error) {
+		success, err := policyParam(params, "success")
+		if err != nil {
+			return nil, err
+		}
+		failure, err := policyParam(params, "failure")
+		if err != nil {
+			return nil, err
+		}
+		return Parallel(success, failure, children...), nil
+	})
+	r.Register("Fork", func(_ map[string]any, children []Behavior) (Behavior, error) {
+		if len(children) != 2 {
+			return nil, fmt.Errorf("bt: Fork requires exactly two children")
+		}
+		return Fork(children[0], children[1]), nil
+	})
+	r.Register("WaitTicks", func(params map[string]any, children []Behavior) (Behavior, error) {
+		if len(children) != 0 {
+			return nil, fmt.Errorf("bt: WaitTicks takes no children")
+		}
+		n, err := intParam(params, "WaitTicks", "n")
+		if err != nil {
+			return nil, err
+		}
+		return WaitTicks(n), nil
+	})
+	return r
+}
+
+// intParam gets an integer parameter.
+func intParam(params map[string]any, typ, key string) (int, error) {
+	v, ok := params[key].(float64)
+	if !ok || v != float64(int(v)) {
+		return 0, fmt.Errorf("bt: %s requires integer parameter %q", typ, key)
+	}
+	return int(v), nil
+}
+
+// policyParam gets a Policy parameter, which is either "one" or "all".
+func policyParam(params map[string]any, key string) (Policy, error) {
+	switch params[key] {
+	case "one":
+		return RequireOne, nil
+	case "all":
+		return RequireAll, nil
+	default:
+		return 0, fmt.Errorf("bt: Parallel requires parameter %q of \"one\" or \"all\"", key)
+	}
+}
+
+// Register registers a Factory under the given type name, replacing any
+// Factory already registered under that name.
+func (r *Registry) Register(typ string, f Factory) {
+	r.factories[typ] = f
+}
+
+// RegisterLeaf registers a leaf Behavior under the given type name. Each use
+// of the type gets a Clone of b, which is Named with the type name so that the
+// tree can be marshaled again with MarshalTree.
+func (r *Registry) RegisterLeaf(typ string, b Behavior) {
+	r.Register(typ, func(_ map[string]any, children []Behavior) (Behavior, error) {
+		if len(children) != 0 {
+			return nil, fmt.Errorf("bt: %s takes no children", typ)
+		}
+		return Named(typ, Clone(b)), nil
+	})
+}
+
+// jsonNode is the JSON description of a Behavior.
+type jsonNode struct {
+	Type     string         `json:"type"`
+	Name     string         `json:"name,omitempty"`
+	Params   map[string]any `json:"params,omitempty"`
+	Children []jsonNode     `json:"children,omitempty"`
+}
+
+// UnmarshalJSON builds a tree from its JSON description, using reg to build
+// each node. Each node is an object with a "type" naming a registered Factory,
+// along with optional "params", "children", and a "name" which wraps the node
+// with Named. For example:
+//
+//	{"type": "Sequence", "name": "attack", "children": [
+//		{"type": "HasTarget"},
+//		{"type": "RetryN", "params": {"n": 3}, "children": [{"type": "Shoot"}]}
+//	]}
+func UnmarshalJSON(data []byte, reg *Registry) (Behavior, error) {
+	var n jsonNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	return reg.build(n)
+}
+
+// build builds the Behavior described by n.
+func (r *Registry) build(n jsonNode) (Behavior, error) {
+	f, ok := r.factories[n.Type]
+	if !ok {
+		return nil, fmt.Errorf("bt: unregistered type %q", n.Type)
+	}
+	children := make([]Behavior, len(n.Children))
+	for i, c := range n.Children {
+		child, err := r.build(c)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = child
+	}
+	b, err := f(n.Params, children)
+	if err != nil {
+		return nil, err
+	}
+	if n.Name != "" {
+		b = Named(n.Name, b)
+	}
+	return b, nil
+}
+
+// MarshalTree gets the JSON description of the tree rooted at root, in the
+// format read by UnmarshalJSON. Only the structure of the tree is described:
+// leaves are described by NameOf, so they should be Named with the type they
+// were registered under.
+func MarshalTree(root Behavior) ([]byte, error) {
+	n, err := describe(root)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(n)
+}
+
+// describe gets the JSON description of b.
+func describe(b Behavior) (jsonNode, error) {
+	if b == nil {
+		return jsonNode{}, fmt.Errorf("bt: nil Behavior in tree")
+	}
+	if n, ok := b.(*named); ok {
+		if _, ok := n.node.(Composite); !ok {
+			return jsonNode{Type: n.name}, nil
+		}
+		d, err := describe(n.node)
+		d.Name = n.name
+		return d, err
+	}
+	d := describeNode(b)
+	if c, ok := b.(Composite); ok {
+		for _, child := range c.Children() {
+			cd, err := describe(child)
+			if err != nil {
+				return jsonNode{}, err
+			}
+			d.Children = append(d.Children, cd)
+		}
+	}
+	return d, nil
+}
+
+// describeNode gets the JSON description of b alone, without its children.
+func describeNode(b Behavior) jsonNode {
+	d := jsonNode{Type: NameOf(b)}
+	switch b := b.(type) {
+	case *repeatN:
+		d.Params = map[string]any{"n": b.n}
+	case *retryN:
+		d.Params = map[string]any{"n": b.n}
+	case *limit:
+		d.Params = map[string]any{"max": b.max}
+	case *delay:
+		d.Params = map[string]any{"ticks": b.ticks}
+	case *cooldown:
+		d.Params = map[string]any{"ticks": b.ticks}
+	case *debounce:
+		d.Params = map[string]any{"ticks": b.ticks}
+	case *everyN:
+		d.Params = map[string]any{"n": b.n}
+	case *circuitBreaker:
+		d.Params = map[string]any{"threshold": b.threshold}
+		if b.clock != nil {
+			d.Params["cooldown"] = b.cooldown.String()
+		}
+	case *maxSuccess:
+		d.Params = map[string]any{"n": b.n}
+	case *waitTicks:
+		d.Params = map[string]any{"n": b.n}
+	case *parallelN:
+		d.Params = map[string]any{"threshold": b.threshold}
+	case *atLeast:
+		d.Params = map[string]any{"n": b.n}
+	case *parallel:
+		policies := map[Policy]string{RequireOne: "one", RequireAll: "all"}
+		switch {
+		case b.resetOnFail:
+			d.Type = "PSequenceResetOnFail"
+		case b.race:
+			d.Type = "Race"
+		case b.batch > 0:
+			d.Type = "PSequenceBatched"
+			d.Params = map[string]any{"batch": b.batch}
+		case b.success == RequireAll && b.failure == RequireOne:
+			d.Type = "PSequence"
+		case b.success == RequireOne && b.failure == RequireAll:
+			d.Type = "PSelection"
+		default:
+			d.Params = map[string]any{
+				"success": policies[b.success],
+				"failure": policies[b.failure],
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,75 @@
+package bt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnmarshalJSON(t *testing.T) {
+	reg := NewRegistry()
+	hasTarget := true
+	shots := 0
+	reg.RegisterLeaf("HasTarget", Conditional(func() bool { return hasTarget }))
+	reg.RegisterLeaf("Shoot", Action(func() State {
+		shots++
+		return Success
+	}))
+	data := []byte(`{"type": "Sequence", "name": "attack", "children": [
+		{"type": "HasTarget"},
+		{"type": "RepeatN", "params": {"n": 2}, "children": [{"type": "Shoot"}]}
+	]}`)
+	b, err := UnmarshalJSON(data, reg)
+	if err != nil {
+		t.Fatal("UnmarshalJSON produced unexpected error:", err)
+	}
+	CheckBehavior("UnmarshalJSON", t, b, []State{Running, Success})
+	if shots != 2 {
+		t.Error("UnmarshalJSON built incorrect tree", shots)
+	}
+	if NameOf(b) != "attack" {
+		t.Error("UnmarshalJSON failed to name node")
+	}
+}
+
+func TestUnmarshalJSON_Errors(t *testing.T) {
+	cases := []string{
+		`{"type": "Missing"}`,
+		`{"type": "Invert"}`,
+		`{"type": "RepeatN", "children": [{"type": "Sequence"}]}`,
+		`{"type": "Parallel", "params": {"success": "some", "failure": "all"}}`,
+		`{"type": "CircuitBreaker", "params": {"threshold": 2, "cooldown": 1}, "children": [{"type": "Sequence"}]}`,
+		`not json`,
+	}
+	for _, c := range cases {
+		if _, err := UnmarshalJSON([]byte(c), NewRegistry()); err == nil {
+			t.Error("UnmarshalJSON failed to report error for", c)
+		}
+	}
+}
+
+func TestMarshalTree(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterLeaf("Idle", Action(func() State { return Running }))
+	data := `{"type":"Selection","name":"root","children":[` +
+		`{"type":"Parallel","params":{"failure":"all","success":"all"},"children":[` +
+		`{"type":"Idle"},{"type":"WaitTicks","params":{"n":3}}]},` +
+		`{"type":"PSequence"},{"type":"PSequenceResetOnFail"},` +
+		`{"type":"AtLeast","params":{"n":1},"children":[{"type":"Idle"}]},` +
+		`{"type":"Cooldown","params":{"ticks":2},"children":[{"type":"Idle"}]},` +
+		`{"type":"CircuitBreaker","params":{"cooldown":"1s","threshold":2},"children":[{"type":"Idle"}]}]}`
+	b, err := UnmarshalJSON([]byte(data), reg)
+	if err != nil {
+		t.Fatal("UnmarshalJSON produced unexpected error:", err)
+	}
+	actual, err := MarshalTree(b)
+	if err != nil {
+		t.Fatal("MarshalTree produced unexpected error:", err)
+	}
+	var expected, got any
+	json.Unmarshal([]byte(data), &expected)
+	json.Unmarshal(actual, &got)
+	if !reflect.DeepEqual(expected, got) {
+		t.Error("MarshalTree produced incorrect JSON:", string(actual))
+	}
+}
@@ -0,0 +1,44 @@
+package bt
+
+import (
+	"context"
+	"log"
+)
+
+// logged is a Behavior which logs the executions and resets of another
+// Behavior.
+type logged struct {
+	wrapper
+	logger *log.Logger
+	prefix string
+}
+
+// Log wraps a Behavior so that each result it returns is logged to logger as
+// "prefix: State", and each Reset as "prefix: Reset". The Behavior is otherwise
+// unchanged. If logger is nil, nothing is logged. For an indented trace of a
+// whole tree, use TraceTree instead.
+func Log(b Behavior, logger *log.Logger, prefix string) Behavior {
+	return &logged{wrapper{node: b}, logger, prefix}
+}
+
+// Reset logs the reset and resets the underlying Behavior.
+func (l *logged) Reset() {
+	if l.logger != nil {
+		l.logger.Printf("%s: Reset", l.prefix)
+	}
+	l.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, logging and returning the result.
+func (l *logged) Execute() State {
+	return Execute(context.Background(), l)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (l *logged) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, l.node)
+	if l.logger != nil {
+		l.logger.Printf("%s: %v", l.prefix, s)
+	}
+	return s
+}
@@ -0,0 +1,22 @@
+package bt
+
+import (
+	"bytes"
+	"log"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+	b := Log(Sequence(Recorded(Running, Success), Recorded(Failure)), logger, "plan")
+	CheckBehavior("Log", t, b, []State{Running, Failure})
+	b.Reset()
+	expected := "plan: Running\nplan: Failure\nplan: Reset\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Log produced incorrect lines:\n%s", actual)
+	}
+	b = Log(Recorded(Running, Success), nil, "silent")
+	CheckBehavior("Log (Nil)", t, b, []State{Running, Success})
+	b.Reset()
+}
@@ -0,0 +1,52 @@
+package bt
+
+import "sync"
+
+// ResetAll resets each of the given Behavior, skipping any which are nil.
+func ResetAll(bs ...Behavior) {
+	for _, b := range bs {
+		if b != nil {
+			b.Reset()
+		}
+	}
+}
+
+// Pool recycles trees, so that spawning many agents which use the same tree
+// need not construct a fresh tree for each. It is safe for concurrent use,
+// though each tree it returns should only be used by one agent at a time.
+type Pool struct {
+	mu   sync.Mutex
+	fn   func() Behavior
+	free []Behavior
+}
+
+// NewPool gets an empty Pool which calls fn to construct a tree whenever there
+// is none to recycle.
+func NewPool(fn func() Behavior) *Pool {
+	return &Pool{fn: fn}
+}
+
+// Get gets a tree which was returned to the Pool, or constructs a new one if
+// there is none. Either way, the tree is ready to start from scratch.
+func (p *Pool) Get() Behavior {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n := len(p.free); n > 0 {
+		b := p.free[n-1]
+		p.free[n-1] = nil
+		p.free = p.free[:n-1]
+		return b
+	}
+	return p.fn()
+}
+
+// Put returns a tree to the Pool once its agent is done with it, so that a
+// later Get can recycle it. The tree is aborted, so that any Running leaf is
+// stopped, and reset, so it must not be used again by the caller afterwards.
+func (p *Pool) Put(b Behavior) {
+	Abort(b)
+	b.Reset()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.free = append(p.free, b)
+}
@@ -0,0 +1,85 @@
+package bt
+
+import (
+	"testing"
+)
+
+// poolTree constructs a tree of moderate size for the Pool tests.
+func poolTree() Behavior {
+	return Selection(
+		Sequence(Recorded(Running, Failure), Invert(Recorded(Success))),
+		ReactiveSequence(Recorded(Success), RepeatN(Recorded(Success), 3)),
+		PSequence(WaitTicks(2), Recorded(Running, Success)),
+	)
+}
+
+func TestResetAll(t *testing.T) {
+	a, b := &testBehavior{base: Recorded(Success)}, &testBehavior{base: Recorded(Success)}
+	ResetAll(a, nil, b)
+	if a.resets != 1 || b.resets != 1 {
+		t.Error("ResetAll failed to reset each Behavior")
+	}
+}
+
+func TestPool(t *testing.T) {
+	built := 0
+	p := NewPool(func() Behavior {
+		built++
+		return &testBehavior{base: Sequence(Recorded(Success), Recorded(Running, Success))}
+	})
+	b := p.Get()
+	CheckBehavior("Pool", t, b, []State{Running})
+	p.Put(b)
+	if b.(*testBehavior).resets != 1 {
+		t.Error("Pool failed to reset returned tree")
+	}
+	if p.Get() != b {
+		t.Error("Pool failed to recycle returned tree")
+	}
+	p.Get()
+	if built != 2 {
+		t.Error("Pool constructed incorrect number of trees:", built)
+	}
+}
+
+func TestPool_Abort(t *testing.T) {
+	var stops []<-chan struct{}
+	p := NewPool(func() Behavior {
+		return Sequence(Recorded(Success), ActionStop(func(stop <-chan struct{}) State {
+			stops = append(stops, stop)
+			return Running
+		}))
+	})
+	b := p.Get()
+	if actual := b.Execute(); actual != Running {
+		t.Fatal("Pool produced tree with incorrect state:", actual)
+	}
+	p.Put(b)
+	select {
+	case <-stops[0]:
+	default:
+		t.Error("Pool failed to abort Running tree")
+	}
+	p.Get().Execute()
+	select {
+	case <-stops[1]:
+		t.Error("Pool recycled tree which was still stopped")
+	default:
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	p := NewPool(poolTree)
+	for i := 0; i < b.N; i++ {
+		tree := p.Get()
+		tree.Execute()
+		p.Put(tree)
+	}
+}
+
+func BenchmarkFresh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := poolTree()
+		tree.Execute()
+	}
+}
@@ -0,0 +1,465 @@
+package bt
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultRand is the source from which randomized Behavior are seeded when no
+// source is given explicitly. It is guarded by defaultRandMu.
+var (
+	defaultRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	defaultRandMu sync.Mutex
+)
+
+// SetDefaultRand sets the source from which randomized Behavior constructed
+// without an explicit source are seeded, so that the randomness of an entire
+// tree is reproducible from one seed, provided it is constructed in the same
+// order. Each Behavior takes its seed at construction and then owns its own
+// source, so only construction draws from r. SetDefaultRand and construction
+// are safe for concurrent use, but r must not be used elsewhere concurrently.
+func SetDefaultRand(r *rand.Rand) {
+	defaultRandMu.Lock()
+	defer defaultRandMu.Unlock()
+	defaultRand = r
+}
+
+// newRand gets a *rand.Rand seeded from the default source.
+func newRand() *rand.Rand {
+	defaultRandMu.Lock()
+	defer defaultRandMu.Unlock()
+	return rand.New(rand.NewSource(defaultRand.Int63()))
+}
+
+// shuffle randomly permutes the child Behavior.
+func (c *composite) shuffle(r *rand.Rand) {
+	r.Shuffle(len(c.nodes), func(i, j int) {
+		c.nodes[i], c.nodes[j] = c.nodes[j], c.nodes[i]
+	})
+}
+
+// randomSelection is a selection which attempts child Behavior in random order.
+type randomSelection struct {
+	selection
+	rng *rand.Rand
+}
+
+// RandomSelection gets a Behavior with the disjunction of child Behavior,
+// which are attempted in a random order on each activation.
+func RandomSelection(bs ...Behavior) Behavior {
+	return RandomSelectionSeeded(newRand(), bs...)
+}
+
+// RandomSelectionSeeded is like RandomSelection, but uses the given source of
+// randomness to order the child Behavior.
+func RandomSelectionSeeded(r *rand.Rand, bs ...Behavior) Behavior {
+	nodes := append([]Behavior(nil), bs...)
+	s := &randomSelection{selection{composite{nodes: nodes}}, r}
+	s.shuffle(r)
+	return s
+}
+
+// Reset resets all child Behavior and chooses a new order for them.
+func (s *randomSelection) Reset() {
+	s.composite.Reset()
+	s.shuffle(s.rng)
+}
+
+// randomSequence is a sequence which runs child Behavior in random order.
+type randomSequence struct {
+	sequence
+	rng *rand.Rand
+}
+
+// RandomSequence gets a Behavior with the conjunction of child Behavior, which
+// are run in a random order on each activation. Note that if the child
+// Behavior have side effects, the random order may change the outcome.
+func RandomSequence(bs ...Behavior) Behavior {
+	return RandomSequenceSeeded(newRand(), bs...)
+}
+
+// RandomSequenceSeeded is like RandomSequence, but uses the given source of
+// randomness to order the child Behavior.
+func RandomSequenceSeeded(r *rand.Rand, bs ...Behavior) Behavior {
+	nodes := append([]Behavior(nil), bs...)
+	s := &randomSequence{sequence{composite{nodes: nodes}}, r}
+	s.shuffle(r)
+	return s
+}
+
+// Reset resets all child Behavior and chooses a new order for them.
+func (s *randomSequence) Reset() {
+	s.composite.Reset()
+	s.shuffle(s.rng)
+}
+
+// ordered is the base of a selection which attempts child Behavior in a
+// chosen order. The chosen order is a permutation of all the child Behavior.
+type ordered struct {
+	selection
+	all  []Behavior
+	perm []int
+}
+
+// newOrdered gets an ordered selection which starts with the given order.
+func newOrdered(bs []Behavior) ordered {
+	o := ordered{
+		selection: selection{composite{nodes: append([]Behavior(nil), bs...)}},
+		all:       append([]Behavior(nil), bs...),
+		perm:      make([]int, len(bs)),
+	}
+	for i := range o.perm {
+		o.perm[i] = i
+	}
+	return o
+}
+
+// apply arranges the child Behavior according to the chosen permutation.
+func (o *ordered) apply() {
+	for k, i := range o.perm {
+		o.nodes[k] = o.all[i]
+	}
+}
+
+// replace replaces the child at index i of the current order.
+func (o *ordered) replace(i int, b Behavior) {
+	o.nodes[i] = b
+	o.all[o.perm[i]] = b
+}
+
+// weightedSelection is a selection which attempts child Behavior in a random
+// order biased by their weights.
+type weightedSelection struct {
+	ordered
+	weights []float64
+	rng     *rand.Rand
+}
+
+// WeightedSelection gets a Behavior with the disjunction of child Behavior,
+// which are attempted in a random order on each activation. Each child is
+// attempted earlier with probability proportional to its weight, and children
+// with non-positive weights are only attempted after all others. It panics if
+// there is not exactly one weight per child.
+func WeightedSelection(weights []float64, bs ...Behavior) Behavior {
+	return WeightedSelectionSeeded(newRand(), weights, bs...)
+}
+
+// WeightedSelectionSeeded is like WeightedSelection, but uses the given source
+// of randomness to order the child Behavior.
+func WeightedSelectionSeeded(r *rand.Rand, weights []float64, bs ...Behavior) Behavior {
+	if len(weights) != len(bs) {
+		panic("bt: WeightedSelection requires exactly one weight per child")
+	}
+	s := &weightedSelection{
+		ordered: newOrdered(bs),
+		weights: append([]float64(nil), weights...),
+		rng:     r,
+	}
+	s.order()
+	return s
+}
+
+// Reset resets all child Behavior and chooses a new order for them.
+func (s *weightedSelection) Reset() {
+	s.composite.Reset()
+	s.order()
+}
+
+// order chooses a weighted random permutation of the child Behavior.
+func (s *weightedSelection) order() {
+	s.perm = s.perm[:0]
+	remaining := make([]int, len(s.all))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	for {
+		total := 0.0
+		for _, i := range remaining {
+			if s.weights[i] > 0 {
+				total += s.weights[i]
+			}
+		}
+		if total <= 0 {
+			break
+		}
+		x := s.rng.Float64() * total
+		pick := -1
+		for k, i := range remaining {
+			if s.weights[i] <= 0 {
+				continue
+			}
+			pick = k
+			if x < s.weights[i] {
+				break
+			}
+			x -= s.weights[i]
+		}
+		s.perm = append(s.perm, remaining[pick])
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+	s.perm = append(s.perm, remaining...)
+	s.apply()
+}
+
+// prioritySelection is a selection which attempts child Behavior in order of
+// their scores.
+type prioritySelection struct {
+	ordered
+	score  func(i int) float64
+	rng    *rand.Rand
+	scored bool
+}
+
+// PrioritySelection gets a Behavior with the disjunction of child Behavior,
+// which are attempted from highest to lowest score. The score of each child is
+// computed from its index on the first execution of each activation, so scores
+// are not recomputed while a child is Running. Ties are broken randomly.
+func PrioritySelection(score func(i int) float64, bs ...Behavior) Behavior {
+	return PrioritySelectionSeeded(newRand(), score, bs...)
+}
+
+// PrioritySelectionSeeded is like PrioritySelection, but uses the given source
+// of randomness to break ties.
+func PrioritySelectionSeeded(r *rand.Rand, score func(i int) float64, bs ...Behavior) Behavior {
+	return &prioritySelection{ordered: newOrdered(bs), score: score, rng: r}
+}
+
+// Reset resets all child Behavior so they are scored again on next execution.
+func (s *prioritySelection) Reset() {
+	s.composite.Reset()
+	s.scored = false
+}
+
+// Execute scores the child Behavior if this is a new activation, and then runs
+// them in order of score. It immediately succeeds if any child succeeds, but
+// fails if all child Behavior fail.
+func (s *prioritySelection) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *prioritySelection) ExecuteCtx(ctx context.Context) State {
+	if !s.scored {
+		s.order()
+		s.scored = true
+	}
+	return s.selection.ExecuteCtx(ctx)
+}
+
+// order sorts the child Behavior by score, breaking ties randomly.
+func (s *prioritySelection) order() {
+	scores := make([]float64, len(s.all))
+	for i := range scores {
+		scores[i] = s.score(i)
+	}
+	s.sortByScore(s.rng, scores)
+}
+
+// sortByScore orders the child Behavior from highest to lowest score, using r
+// to break ties.
+func (o *ordered) sortByScore(r *rand.Rand, scores []float64) {
+	o.perm = r.Perm(len(o.all))
+	sort.SliceStable(o.perm, func(a, b int) bool {
+		return scores[o.perm[a]] > scores[o.perm[b]]
+	})
+	o.apply()
+}
+
+// utility is a Behavior which pairs another Behavior with a utility score.
+type utility struct {
+	wrapper
+	score func(*Blackboard) float64
+}
+
+// Utility wraps a Behavior to give it a utility, computed by score from the
+// Blackboard, for an enclosing UtilitySelection. The Behavior is otherwise
+// unchanged, and outside a UtilitySelection the score is never computed.
+func Utility(b Behavior, score func(*Blackboard) float64) Behavior {
+	return &utility{wrapper{node: b}, score}
+}
+
+// Execute runs the underlying Behavior and returns the result.
+func (u *utility) Execute() State {
+	return Execute(context.Background(), u)
+}
+
+// ExecuteCtx runs the underlying Behavior with ctx and returns the result.
+func (u *utility) ExecuteCtx(ctx context.Context) State {
+	return Execute(ctx, u.node)
+}
+
+// utilitySelection is a selection which attempts child Behavior in order of
+// their utility.
+type utilitySelection struct {
+	ordered
+	rng    *rand.Rand
+	scored bool
+}
+
+// UtilitySelection gets a Behavior with the disjunction of child Behavior,
+// which are attempted from highest to lowest utility, so that the child with
+// the highest utility is selected unless it fails. The utility of each child
+// wrapped with Utility, even beneath other decorators such as Named or those
+// added by Instrument or TraceTree, is computed from the Blackboard carried by
+// the Context on the first execution of each activation, so utilities are not
+// recomputed while a child is Running; any other child has a utility of 0. Ties are
+// broken randomly. Like ActionBB, it returns Unknown if executed without a
+// Blackboard.
+func UtilitySelection(bs ...Behavior) Behavior {
+	return UtilitySelectionSeeded(newRand(), bs...)
+}
+
+// UtilitySelectionSeeded is like UtilitySelection, but uses the given source of
+// randomness to break ties.
+func UtilitySelectionSeeded(r *rand.Rand, bs ...Behavior) Behavior {
+	return &utilitySelection{ordered: newOrdered(bs), rng: r}
+}
+
+// Reset resets all child Behavior so they are scored again on next execution.
+func (s *utilitySelection) Reset() {
+	s.composite.Reset()
+	s.scored = false
+}
+
+// Execute returns Unknown, since there is no Blackboard without a Context.
+func (s *utilitySelection) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx scores the child Behavior with the Blackboard carried by ctx if
+// this is a new activation, and then runs them in order of utility. It
+// immediately succeeds if any child succeeds, but fails if all child Behavior
+// fail.
+func (s *utilitySelection) ExecuteCtx(ctx context.Context) State {
+	if !s.scored {
+		bb := BlackboardFrom(ctx)
+		if bb == nil {
+			return Unknown
+		}
+		scores := make([]float64, len(s.all))
+		for i, b := range s.all {
+			if u, ok := unwrap[*utility](b); ok {
+				scores[i] = u.score(bb)
+			}
+		}
+		s.sortByScore(s.rng, scores)
+		s.scored = true
+	}
+	return s.selection.ExecuteCtx(ctx)
+}
+
+// roundRobin is a selection which attempts child Behavior starting from a
+// different child on each activation.
+type roundRobin struct {
+	ordered
+	start int
+	ran   bool
+}
+
+// RoundRobin gets a Behavior with the disjunction of child Behavior. The first
+// activation attempts the children in order, and each later activation starts
+// from the child after the one which was run last, wrapping around, so that
+// work is spread evenly over equivalent children. Within an activation, the
+// first child to succeed wins, as in Selection. The starting point deliberately
+// survives Reset; to start over from the first child, use Rewind.
+func RoundRobin(bs ...Behavior) Behavior {
+	return &roundRobin{ordered: newOrdered(bs)}
+}
+
+// Rewinder is a Behavior which keeps state across activations, and which can
+// be reset fully, including that state.
+type Rewinder interface {
+	Rewind()
+}
+
+// Reset resets all child Behavior, and advances the starting point past the
+// child which was run last, if any child was run.
+func (s *roundRobin) Reset() {
+	if s.ran && len(s.perm) > 0 {
+		last := s.perm[min(s.index, len(s.perm)-1)]
+		s.start = (last + 1) % len(s.perm)
+	}
+	s.ran = false
+	s.composite.Reset()
+	for k := range s.perm {
+		s.perm[k] = (s.start + k) % len(s.perm)
+	}
+	s.apply()
+}
+
+// Rewind resets the RoundRobin fully, so that the next activation starts from
+// the first child.
+func (s *roundRobin) Rewind() {
+	s.ran = false
+	s.start = 0
+	s.Reset()
+}
+
+// Execute runs each child Behavior in turn from the starting point. It
+// immediately succeeds if any child succeeds, but fails if all child Behavior
+// fail.
+func (s *roundRobin) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *roundRobin) ExecuteCtx(ctx context.Context) State {
+	s.ran = true
+	return s.selection.ExecuteCtx(ctx)
+}
+
+// chance is a Behavior which succeeds with a fixed probability.
+type chance struct {
+	p   float64
+	rng *rand.Rand
+}
+
+// Chance gets a Behavior which succeeds with probability p on each execution,
+// and fails otherwise. Values of p outside [0, 1] are clamped.
+func Chance(p float64) Behavior {
+	return ChanceSeeded(p, newRand())
+}
+
+// ChanceSeeded is like Chance, but uses the given source of randomness.
+func ChanceSeeded(p float64, r *rand.Rand) Behavior {
+	return &chance{min(max(p, 0), 1), r}
+}
+
+// Reset is a noop.
+func (c *chance) Reset() {}
+
+// Execute succeeds with probability p, and fails otherwise.
+func (c *chance) Execute() State {
+	if c.rng.Float64() < c.p {
+		return Success
+	}
+	return Failure
+}
+
+// ParallelRandom is like Parallel, but on each execution the incomplete child
+// Behavior are run in a new random order, so that no child is favored when
+// several compete for the same resource. Only the order changes; the Policy
+// are applied exactly as in Parallel.
+func ParallelRandom(success, failure Policy, bs ...Behavior) Behavior {
+	return ParallelRandomSeeded(newRand(), success, failure, bs...)
+}
+
+// ParallelRandomSeeded is like ParallelRandom, but uses the given source of
+// randomness to order the child Behavior.
+func ParallelRandomSeeded(r *rand.Rand, success, failure Policy, bs ...Behavior) Behavior {
+	p := Parallel(success, failure, bs...).(*parallel)
+	p.rng = r
+	return p
+}
+
+// perm gets a random order in which to run the child Behavior, or nil if the
+// parallel has no source of randomness and runs them in order.
+func (p *parallel) perm() []int {
+	if p.rng == nil {
+		return nil
+	}
+	return p.rng.Perm(len(p.nodes))
+}
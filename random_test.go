@@ -0,0 +1,400 @@
+package bt
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRandomSelection_Success(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Failure)},
+		{base: Recorded(Running, Success)},
+		{base: Recorded(Failure)},
+	}
+	b := RandomSelectionSeeded(rand.New(rand.NewSource(1)),
+		children[0], children[1], children[2])
+	state := Running
+	for state == Running {
+		state = b.Execute()
+	}
+	if state != Success {
+		t.Error("RandomSelection (Success) produced incorrect state:", state)
+	}
+	if children[1].calls != 2 {
+		t.Error("RandomSelection (Success) failed to resume running child")
+	}
+}
+
+func TestRandomSelection_Failure(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Failure)},
+		{base: Recorded(Running, Failure)},
+		{base: Recorded(Failure)},
+	}
+	b := RandomSelectionSeeded(rand.New(rand.NewSource(1)),
+		children[0], children[1], children[2])
+	expected := []State{Running, Failure}
+	CheckBehavior("RandomSelection (Failure)", t, b, expected)
+	for i, c := range children {
+		if c.calls == 0 {
+			t.Error("RandomSelection (Failure) failed to execute child", i)
+		}
+	}
+}
+
+func TestRandomSelection_Order(t *testing.T) {
+	var order []int
+	var bs []Behavior
+	for i := 0; i < 5; i++ {
+		bs = append(bs, Action(func() State {
+			order = append(order, i)
+			return Failure
+		}))
+	}
+	b := RandomSelectionSeeded(rand.New(rand.NewSource(1)), bs...)
+	seen := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		order = nil
+		b.Execute()
+		b.Reset()
+		if len(order) != len(bs) {
+			t.Fatal("RandomSelection failed to execute every child", order)
+		}
+		seen[fmt.Sprint(order)] = true
+	}
+	if len(seen) < 2 {
+		t.Error("RandomSelection failed to shuffle children on Reset")
+	}
+}
+
+func TestRandomSequence_Success(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Success)},
+		{base: Recorded(Running, Success)},
+		{base: Recorded(Success)},
+	}
+	b := RandomSequenceSeeded(rand.New(rand.NewSource(1)),
+		children[0], children[1], children[2])
+	expected := []State{Running, Success}
+	CheckBehavior("RandomSequence (Success)", t, b, expected)
+	for i, c := range children {
+		if c.calls == 0 {
+			t.Error("RandomSequence (Success) failed to execute child", i)
+		}
+	}
+	if children[1].calls != 2 {
+		t.Error("RandomSequence (Success) failed to resume running child")
+	}
+}
+
+func TestRandomSequence_Failure(t *testing.T) {
+	children := []*testBehavior{
+		{base: Recorded(Success)},
+		{base: Recorded(Failure)},
+		{base: Recorded(Success)},
+	}
+	b := RandomSequenceSeeded(rand.New(rand.NewSource(1)),
+		children[0], children[1], children[2])
+	CheckBehavior("RandomSequence (Failure)", t, b, []State{Failure})
+	if children[1].calls != 1 {
+		t.Error("RandomSequence (Failure) failed to execute failing child")
+	}
+}
+
+func TestWeightedSelection(t *testing.T) {
+	first := make(map[int]int)
+	last := make(map[int]int)
+	var order []int
+	var bs []Behavior
+	for i := 0; i < 3; i++ {
+		bs = append(bs, Action(func() State {
+			order = append(order, i)
+			return Failure
+		}))
+	}
+	b := WeightedSelectionSeeded(rand.New(rand.NewSource(1)), []float64{0, 1, 9}, bs...)
+	for i := 0; i < 100; i++ {
+		order = nil
+		if actual := b.Execute(); actual != Failure {
+			t.Fatal("WeightedSelection produced incorrect state:", actual)
+		}
+		b.Reset()
+		if len(order) != len(bs) {
+			t.Fatal("WeightedSelection failed to execute every child", order)
+		}
+		first[order[0]]++
+		last[order[len(order)-1]]++
+	}
+	if last[0] != 100 {
+		t.Error("WeightedSelection attempted zero weight child early", last)
+	}
+	if first[2] <= first[1] {
+		t.Error("WeightedSelection failed to bias order by weight", first)
+	}
+}
+
+func TestWeightedSelection_Mismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WeightedSelection failed to panic on mismatched weights")
+		}
+	}()
+	WeightedSelection([]float64{1}, Recorded(Success), Recorded(Success))
+}
+
+func TestUtilitySelection(t *testing.T) {
+	var order []string
+	act := func(name string, states ...State) Behavior {
+		r := Recorded(states...)
+		return Action(func() State {
+			order = append(order, name)
+			return r.Execute()
+		})
+	}
+	read := func(key string) func(*Blackboard) float64 {
+		return func(bb *Blackboard) float64 {
+			v, _ := bb.Get(key)
+			return v.(float64)
+		}
+	}
+	b := UtilitySelectionSeeded(rand.New(rand.NewSource(1)),
+		Utility(act("eat", Success), read("hunger")),
+		Utility(act("fight", Running, Success, Failure), read("threat")),
+		act("idle", Success),
+	)
+	bb := &Blackboard{}
+	ctx := WithBlackboard(context.Background(), bb)
+	bb.Set("hunger", 1.0)
+	bb.Set("threat", 5.0)
+	if actual := Execute(ctx, b); actual != Running {
+		t.Error("UtilitySelection produced incorrect state:", actual)
+	}
+	bb.Set("threat", 0.0)
+	if actual := Execute(ctx, b); actual != Success {
+		t.Error("UtilitySelection produced incorrect state:", actual)
+	}
+	if !reflect.DeepEqual([]string{"fight", "fight"}, order) {
+		t.Error("UtilitySelection rescored children within activation", order)
+	}
+	b.Reset()
+	order = nil
+	bb.Set("hunger", -1.0)
+	bb.Set("threat", 3.0)
+	if actual := Execute(ctx, b); actual != Success {
+		t.Error("UtilitySelection produced incorrect state:", actual)
+	}
+	if !reflect.DeepEqual([]string{"fight", "idle"}, order) {
+		t.Error("UtilitySelection attempted children in incorrect order", order)
+	}
+	b.Reset()
+	if actual := b.Execute(); actual != Unknown {
+		t.Error("UtilitySelection produced incorrect state without Blackboard:", actual)
+	}
+}
+
+func TestUtilitySelection_Wrapped(t *testing.T) {
+	score := func(v float64) func(*Blackboard) float64 {
+		return func(*Blackboard) float64 { return v }
+	}
+	low := Named("low", Utility(Recorded(Success), score(0)))
+	high := Named("high", Utility(Recorded(Success), score(10)))
+	b, stats := Instrument(UtilitySelectionSeeded(rand.New(rand.NewSource(1)), low, high))
+	ctx := WithBlackboard(context.Background(), &Blackboard{})
+	for k := 0; k < 5; k++ {
+		Execute(ctx, b)
+		b.Reset()
+	}
+	if stats.Get(low).Executes != 0 || stats.Get(high).Executes != 5 {
+		t.Error("UtilitySelection (Instrument) selected incorrect children:",
+			stats.Get(low).Executes, stats.Get(high).Executes)
+	}
+	traced, buf := TraceTree(UtilitySelectionSeeded(rand.New(rand.NewSource(1)),
+		Named("low", Utility(Recorded(Success), score(0))),
+		Named("high", Utility(Recorded(Success), score(10))),
+	))
+	for k := 0; k < 5; k++ {
+		Execute(ctx, traced)
+		if trace := buf.String(); !strings.Contains(trace, "high: Success") || strings.Contains(trace, "low:") {
+			t.Errorf("UtilitySelection (TraceTree) selected incorrect child:\n%s", trace)
+		}
+		traced.Reset()
+	}
+}
+
+func TestPrioritySelection(t *testing.T) {
+	scores := []float64{1, 3, 2}
+	calls := 0
+	var order []int
+	var bs []Behavior
+	for i := range scores {
+		bs = append(bs, Action(func() State {
+			order = append(order, i)
+			if i == 0 {
+				return Success
+			}
+			return Failure
+		}))
+	}
+	score := func(i int) float64 {
+		calls++
+		return scores[i]
+	}
+	b := PrioritySelectionSeeded(rand.New(rand.NewSource(1)), score, bs...)
+	CheckBehavior("PrioritySelection", t, b, []State{Success})
+	if !reflect.DeepEqual([]int{1, 2, 0}, order) {
+		t.Error("PrioritySelection attempted children in incorrect order", order)
+	}
+	b.Execute()
+	if calls != 3 {
+		t.Error("PrioritySelection rescored children within activation", calls)
+	}
+	scores[0] = 4
+	order = nil
+	b.Reset()
+	CheckBehavior("PrioritySelection (Reset)", t, b, []State{Success})
+	if !reflect.DeepEqual([]int{0}, order) {
+		t.Error("PrioritySelection failed to rescore children on Reset", order)
+	}
+}
+
+func TestPrioritySelection_Running(t *testing.T) {
+	calls := 0
+	score := func(i int) float64 {
+		calls++
+		return float64(i)
+	}
+	b := PrioritySelectionSeeded(rand.New(rand.NewSource(1)), score,
+		Recorded(Failure), Recorded(Running, Failure))
+	CheckBehavior("PrioritySelection (Running)", t, b, []State{Running, Failure})
+	if calls != 2 {
+		t.Error("PrioritySelection (Running) rescored running children", calls)
+	}
+}
+
+func TestChance(t *testing.T) {
+	cases := []struct {
+		p        float64
+		min, max int
+	}{
+		{-1, 0, 0},
+		{0, 0, 0},
+		{0.3, 200, 400},
+		{1, 1000, 1000},
+		{2, 1000, 1000},
+	}
+	for _, c := range cases {
+		b := ChanceSeeded(c.p, rand.New(rand.NewSource(1)))
+		successes := 0
+		for i := 0; i < 1000; i++ {
+			if b.Execute() == Success {
+				successes++
+			}
+		}
+		if successes < c.min || successes > c.max {
+			t.Errorf("Chance (%v) produced incorrect successes: %d", c.p, successes)
+		}
+	}
+}
+
+func TestChance_Seeded(t *testing.T) {
+	a := ChanceSeeded(0.5, rand.New(rand.NewSource(1)))
+	b := ChanceSeeded(0.5, rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		if a.Execute() != b.Execute() {
+			t.Fatal("Chance produced different results from same seed")
+		}
+	}
+}
+
+func TestSetDefaultRand(t *testing.T) {
+	defer SetDefaultRand(rand.New(rand.NewSource(1)))
+	run := func() []State {
+		SetDefaultRand(rand.New(rand.NewSource(7)))
+		b := Selection(
+			Chance(0.5),
+			RandomSequence(Chance(0.5), Chance(0.5)),
+		)
+		actual := make([]State, 20)
+		for i := range actual {
+			actual[i] = b.Execute()
+			b.Reset()
+		}
+		return actual
+	}
+	if a, b := run(), run(); !reflect.DeepEqual(a, b) {
+		t.Error("SetDefaultRand failed to make tree reproducible:", a, b)
+	}
+}
+
+func TestParallelRandom(t *testing.T) {
+	var order []int
+	children := make([]Behavior, 3)
+	for i := range children {
+		children[i] = Action(func() State {
+			order = append(order, i)
+			return Running
+		})
+	}
+	b := ParallelRandomSeeded(rand.New(rand.NewSource(1)), RequireAll, RequireOne, children...)
+	orders := make(map[string]bool)
+	for k := 0; k < 10; k++ {
+		order = nil
+		if actual := b.Execute(); actual != Running {
+			t.Fatal("ParallelRandom produced incorrect state:", actual)
+		}
+		if len(order) != len(children) {
+			t.Fatal("ParallelRandom failed to run every child:", order)
+		}
+		orders[fmt.Sprint(order)] = true
+	}
+	if len(orders) < 2 {
+		t.Error("ParallelRandom failed to vary the order of children")
+	}
+}
+
+func TestParallelRandom_Policy(t *testing.T) {
+	b := ParallelRandomSeeded(rand.New(rand.NewSource(1)), RequireAll, RequireOne,
+		Recorded(Running, Success),
+		Recorded(Success),
+		Recorded(Running, Running, Failure),
+	)
+	CheckBehavior("ParallelRandom (Policy)", t, b, []State{Running, Running, Failure})
+}
+
+func TestRoundRobin(t *testing.T) {
+	var order []int
+	children := make([]Behavior, 3)
+	for i := range children {
+		children[i] = Action(func() State {
+			order = append(order, i)
+			if i == 1 {
+				return Failure
+			}
+			return Success
+		})
+	}
+	b := RoundRobin(children...)
+	for k := 0; k < 4; k++ {
+		if actual := b.Execute(); actual != Success {
+			t.Error("RoundRobin produced incorrect state:", actual)
+		}
+		b.Reset()
+	}
+	b.Reset()
+	expected := []int{0, 1, 2, 0, 1, 2}
+	if !reflect.DeepEqual(expected, order) {
+		t.Error("RoundRobin ran children in incorrect order:", order)
+	}
+	order = nil
+	b.Execute()
+	b.Reset()
+	b.(Rewinder).Rewind()
+	b.Execute()
+	if !reflect.DeepEqual([]int{0, 0}, order) {
+		t.Error("RoundRobin failed to start from the first child after Rewind:", order)
+	}
+}
@@ -0,0 +1,85 @@
+package bt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visit is the execution of a single Behavior during a recorded tick.
+type Visit struct {
+	Depth  int
+	Name   string
+	Result State
+}
+
+// String gets the indented NameOf and result of the visited Behavior, in the
+// format used by TraceTree.
+func (v Visit) String() string {
+	return fmt.Sprintf("%s%s: %v", strings.Repeat("  ", v.Depth), v.Name, v.Result)
+}
+
+// Recording is a log of the Behavior visited on each execution of a tree, as
+// produced by Recorder. Ticks holds one slice of Visit per execution of the
+// root, in the order the Behavior were executed. Recordings hold only names and
+// results, so recordings of different trees can be compared with Equal or
+// reflect.DeepEqual.
+type Recording struct {
+	Ticks [][]Visit
+}
+
+// Equal reports whether r and o recorded the same visits on every tick.
+func (r *Recording) Equal(o *Recording) bool {
+	if len(r.Ticks) != len(o.Ticks) {
+		return false
+	}
+	for i := range r.Ticks {
+		if len(r.Ticks[i]) != len(o.Ticks[i]) {
+			return false
+		}
+		for j := range r.Ticks[i] {
+			if r.Ticks[i][j] != o.Ticks[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Recorder wraps each Behavior in the tree rooted at root so that every
+// execution of the root appends the Behavior it visited to the returned
+// Recording. Like TraceTree, the tree is modified in place, so only the
+// returned Behavior should be used afterwards.
+func Recorder(root Behavior) (Behavior, *Recording) {
+	r := &Recording{}
+	return traceTree(root, func(lines []traceLine) {
+		tick := make([]Visit, len(lines))
+		for i, l := range lines {
+			tick[i] = Visit{l.depth, NameOf(l.node), l.result}
+		}
+		r.Ticks = append(r.Ticks, tick)
+	}), r
+}
+
+// Replay executes the tree rooted at root once for each tick of recording, and
+// reports the first tick on which root visits different Behavior or produces
+// different results than were recorded, such as after the tree is refactored.
+// It returns nil if every tick matches. The tree is modified in place as by
+// Recorder, and should be freshly built or Reset beforehand.
+func Replay(recording *Recording, root Behavior) error {
+	b, actual := Recorder(root)
+	for i, expected := range recording.Ticks {
+		b.Execute()
+		got := actual.Ticks[i]
+		for j := 0; j < len(expected) || j < len(got); j++ {
+			switch {
+			case j >= len(got):
+				return fmt.Errorf("bt: tick %d: missing visit %q", i, expected[j])
+			case j >= len(expected):
+				return fmt.Errorf("bt: tick %d: unexpected visit %q", i, got[j])
+			case expected[j] != got[j]:
+				return fmt.Errorf("bt: tick %d: visit %q != %q", i, got[j], expected[j])
+			}
+		}
+	}
+	return nil
+}
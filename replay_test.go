@@ -0,0 +1,54 @@
+package bt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	build := func(last State) Behavior {
+		return Named("root", Selection(
+			Sequence(Recorded(Running, Failure), Recorded(Success)),
+			Named("fallback", Recorded(last)),
+		))
+	}
+	root, recording := Recorder(build(Success))
+	root.Execute()
+	root.Execute()
+	expected := [][]Visit{
+		{
+			{0, "root", Running},
+			{1, "Selection", Running},
+			{2, "Sequence", Running},
+			{3, "Action", Running},
+		},
+		{
+			{0, "root", Success},
+			{1, "Selection", Success},
+			{2, "Sequence", Failure},
+			{3, "Action", Failure},
+			{2, "fallback", Success},
+			{3, "Action", Success},
+		},
+	}
+	if !reflect.DeepEqual(recording.Ticks, expected) {
+		t.Errorf("Recorder produced incorrect recording: %v", recording.Ticks)
+	}
+	again, other := Recorder(build(Success))
+	if recording.Equal(other) {
+		t.Error("Recording.Equal compared recordings with different ticks as equal")
+	}
+	again.Execute()
+	again.Execute()
+	if !recording.Equal(other) {
+		t.Error("Recording.Equal compared identical recordings as unequal")
+	}
+	if err := Replay(recording, build(Success)); err != nil {
+		t.Error("Replay produced unexpected error:", err)
+	}
+	err := Replay(recording, build(Failure))
+	if err == nil || !strings.Contains(err.Error(), "tick 1") {
+		t.Error("Replay failed to report drift:", err)
+	}
+}
@@ -0,0 +1,115 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Runner drives the root of a behavior tree, resetting it after each run.
+type Runner struct {
+	root Behavior
+	runs int
+}
+
+// NewRunner gets a Runner for the tree rooted at root.
+func NewRunner(root Behavior) *Runner {
+	return &Runner{root: root}
+}
+
+// Tick executes the root and returns the result. If the root succeeds or
+// fails, the run is counted and the root is reset, so the next Tick starts a
+// new run from scratch.
+func (r *Runner) Tick() State {
+	return r.TickCtx(context.Background())
+}
+
+// TickCtx is like Tick, but executes the root with ctx.
+func (r *Runner) TickCtx(ctx context.Context) State {
+	s := Execute(ctx, r.root)
+	if s == Success || s == Failure {
+		r.runs++
+		r.root.Reset()
+	}
+	return s
+}
+
+// Runs gets the number of runs which have completed.
+func (r *Runner) Runs() int {
+	return r.runs
+}
+
+// EventLoop ticks root with a Runner each time a value is received from events,
+// so that the tree is driven by external events, such as input or a timer,
+// rather than a busy loop. The result of each tick is sent on the returned
+// channel, which is closed once events is closed. As with Runner, root is reset
+// after it succeeds or fails. The returned channel is unbuffered, so each
+// result must be received before the next event is handled. The tree is
+// executed on a separate goroutine, and must not be executed elsewhere while
+// the loop is running.
+func EventLoop(root Behavior, events <-chan struct{}) <-chan State {
+	out := make(chan State)
+	go func() {
+		defer close(out)
+		r := NewRunner(root)
+		for range events {
+			out <- r.Tick()
+		}
+	}()
+	return out
+}
+
+// budgetKey is the Context key for a budget.
+type budgetKey struct{}
+
+// budget is a deadline for a single execution of a tree.
+type budget struct {
+	clock    Clock
+	deadline time.Time
+}
+
+// exceeded reports whether the deadline has passed.
+func (b *budget) exceeded() bool {
+	return !b.clock.Now().Before(b.deadline)
+}
+
+// RunWithin executes root once, but stops running further Behavior once the
+// given time budget is spent. Within the tree, each Behavior which is not run
+// is reported as Cancelled, so that composites stop where they are rather than
+// mistaking it for a Running child, and RunWithin returns Running. Since a
+// Sequence or Selection resumes from the child it stopped at, executing root
+// again continues the work, so a large tree can be spread over several ticks of
+// a loop with a fixed time budget per tick. The budget is only checked between
+// Behavior, so a single leaf which runs over the budget cannot be interrupted.
+func RunWithin(root Behavior, budget time.Duration) State {
+	return RunWithinClock(root, budget, SystemClock)
+}
+
+// RunWithinClock is like RunWithin, but uses clock to measure the budget.
+func RunWithinClock(root Behavior, d time.Duration, clock Clock) State {
+	b := &budget{clock, clock.Now().Add(d)}
+	s := Execute(context.WithValue(context.Background(), budgetKey{}, b), root)
+	if s == Cancelled && b.exceeded() {
+		return Running
+	}
+	return s
+}
+
+// ErrMaxTicks is returned by RunToCompletion when the tree is still Running
+// after the maximum number of ticks.
+var ErrMaxTicks = errors.New("bt: still Running after maximum ticks")
+
+// RunToCompletion executes root repeatedly until it returns anything other than
+// Running, and returns that State. If root is still Running after maxTicks
+// executions, Running is returned with an error wrapping ErrMaxTicks, so that a
+// tree which never completes cannot hang the caller. Nothing is reset, either
+// before or after.
+func RunToCompletion(root Behavior, maxTicks int) (State, error) {
+	for i := 0; i < maxTicks; i++ {
+		if s := Execute(context.Background(), root); s != Running {
+			return s, nil
+		}
+	}
+	return Running, fmt.Errorf("%w (%d)", ErrMaxTicks, maxTicks)
+}
@@ -0,0 +1,141 @@
+package bt
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	root := &testBehavior{base: Sequence(WaitTicks(1), Recorded(Success, Failure))}
+	r := NewRunner(root)
+	expected := []State{Running, Success, Running, Failure, Running}
+	actual := make([]State, len(expected))
+	for i := range expected {
+		actual[i] = r.Tick()
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("Runner produced incorrect states:", actual)
+	}
+	if r.Runs() != 2 {
+		t.Error("Runner counted incorrect runs:", r.Runs())
+	}
+	if root.resets != 2 {
+		t.Error("Runner failed to reset root after each run:", root.resets)
+	}
+}
+
+func TestEventLoop(t *testing.T) {
+	root := &testBehavior{base: Sequence(WaitTicks(1), Recorded(Success, Failure))}
+	events := make(chan struct{})
+	out := EventLoop(root, events)
+	expected := []State{Running, Success, Running, Failure, Running}
+	go func() {
+		for range expected {
+			events <- struct{}{}
+		}
+		close(events)
+	}()
+	var actual []State
+	for s := range out {
+		actual = append(actual, s)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("EventLoop produced incorrect states:", actual)
+	}
+	if root.resets != 2 {
+		t.Error("EventLoop failed to reset root after each run:", root.resets)
+	}
+}
+
+func TestRunWithin(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	children := make([]Behavior, 5)
+	calls := make([]int, len(children))
+	for i := range children {
+		children[i] = Action(func() State {
+			calls[i]++
+			clock.Advance(time.Millisecond)
+			return Success
+		})
+	}
+	root := Sequence(children...)
+	expected := []State{Running, Running, Success}
+	actual := make([]State, len(expected))
+	for i := range expected {
+		actual[i] = RunWithinClock(root, 2*time.Millisecond, clock)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("RunWithin produced incorrect states:", actual)
+	}
+	if !reflect.DeepEqual([]int{1, 1, 1, 1, 1}, calls) {
+		t.Error("RunWithin ran children incorrectly:", calls)
+	}
+}
+
+func TestRunWithin_Reactive(t *testing.T) {
+	ctors := map[string]func(...Behavior) Behavior{
+		"ReactiveSelection":     ReactiveSelection,
+		"InterruptingSelection": InterruptingSelection,
+	}
+	for name, ctor := range ctors {
+		clock := NewManualClock(time.Unix(0, 0))
+		cut, open := false, false
+		slow := Action(func() State {
+			if cut {
+				clock.Advance(time.Second)
+			}
+			return Success
+		})
+		guard := Conditional(func() bool { return open })
+		body := &testBehavior{base: Recorded(Running)}
+		root := ctor(ReactiveSequence(slow, guard), body)
+		tick := func(expected State) {
+			t.Helper()
+			if actual := RunWithinClock(root, time.Millisecond, clock); actual != expected {
+				t.Errorf("%s produced incorrect state under RunWithin: %v", name, actual)
+			}
+		}
+		tick(Running)
+		cut = true
+		tick(Running)
+		if body.resets != 0 {
+			t.Errorf("%s preempted Running child for a guard which was not run", name)
+		}
+		cut = false
+		tick(Running)
+		if body.calls != 2 || body.resets != 0 {
+			t.Errorf("%s failed to resume Running child after budget was spent", name)
+		}
+		cut = true
+		tick(Running)
+		cut, open = false, true
+		tick(Success)
+		if body.resets != 1 {
+			t.Errorf("%s failed to preempt Running child after budget was spent", name)
+		}
+	}
+}
+
+func TestRunToCompletion(t *testing.T) {
+	root := &testBehavior{base: Recorded(Running, Running, Failure)}
+	s, err := RunToCompletion(root, 5)
+	if s != Failure || err != nil {
+		t.Error("RunToCompletion produced incorrect result:", s, err)
+	}
+	if root.calls != 3 || root.resets != 0 {
+		t.Error("RunToCompletion executed root incorrectly:", root.calls, root.resets)
+	}
+}
+
+func TestRunToCompletion_MaxTicks(t *testing.T) {
+	root := &testBehavior{base: Recorded(Running)}
+	s, err := RunToCompletion(root, 5)
+	if s != Running || !errors.Is(err, ErrMaxTicks) {
+		t.Error("RunToCompletion failed to report exhaustion:", s, err)
+	}
+	if root.calls != 5 {
+		t.Error("RunToCompletion executed root incorrect times:", root.calls)
+	}
+}
@@ -0,0 +1,167 @@
+package bt
+
+import (
+	"context"
+	"reflect"
+)
+
+// NodeStats records how often a Behavior was executed and reset, and the
+// results of its executions.
+type NodeStats struct {
+	Node      Behavior
+	Executes  int
+	Running   int
+	Successes int
+	Failures  int
+	Unknowns  int
+	Cancelled int
+	Resets    int
+}
+
+// record tallies the result of an execution.
+func (n *NodeStats) record(s State) {
+	n.Executes++
+	switch s {
+	case Running:
+		n.Running++
+	case Success:
+		n.Successes++
+	case Failure:
+		n.Failures++
+	case Cancelled:
+		n.Cancelled++
+	default:
+		n.Unknowns++
+	}
+}
+
+// Stats records NodeStats for each Behavior in an instrumented tree.
+type Stats struct {
+	Nodes  []*NodeStats
+	byNode map[Behavior]*NodeStats
+}
+
+// Get gets the NodeStats for b, or nil if b was not instrumented. Behavior with
+// types which are not comparable, such as Action, cannot be looked up; either
+// find them in Nodes, which are in the order visited by Walk, or wrap them with
+// Named and look up the Named Behavior instead.
+func (s *Stats) Get(b Behavior) *NodeStats {
+	if !reflect.TypeOf(b).Comparable() {
+		return nil
+	}
+	return s.byNode[b]
+}
+
+// Instrument wraps each Behavior in the tree rooted at root so that its
+// executions and resets are recorded in the returned Stats. The tree is
+// modified in place, so only the returned Behavior should be used afterwards.
+// Execution is otherwise unchanged. Subtrees which were already instrumented
+// are left alone and continue to record into their own Stats, so a subtree can
+// be instrumented on its own.
+func Instrument(root Behavior) (Behavior, *Stats) {
+	s := &Stats{byNode: make(map[Behavior]*NodeStats)}
+	return s.instrument(root), s
+}
+
+// instrument wraps b and each of its children, if they can be replaced.
+func (s *Stats) instrument(b Behavior) Behavior {
+	if i, ok := b.(*instrumented); ok {
+		return i
+	}
+	n := &NodeStats{Node: b}
+	s.Nodes = append(s.Nodes, n)
+	if reflect.TypeOf(b).Comparable() {
+		s.byNode[b] = n
+	}
+	if r, ok := b.(replacer); ok {
+		for i, child := range b.(Composite).Children() {
+			if child != nil {
+				r.replace(i, s.instrument(child))
+			}
+		}
+	}
+	return &instrumented{wrapper{node: b}, n}
+}
+
+// instrumented is a Behavior which records the executions of another Behavior.
+type instrumented struct {
+	wrapper
+	stats *NodeStats
+}
+
+// Reset records the reset and resets the underlying Behavior.
+func (i *instrumented) Reset() {
+	i.stats.Resets++
+	i.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, recording and returning the result.
+func (i *instrumented) Execute() State {
+	return Execute(context.Background(), i)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (i *instrumented) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, i.node)
+	i.stats.record(s)
+	return s
+}
+
+// CountSnapshot records the results of the executions of a single Behavior
+// since it was last reset.
+type CountSnapshot struct {
+	Running   int
+	Successes int
+	Failures  int
+	Unknowns  int
+	Cancelled int
+}
+
+// record tallies the result of an execution.
+func (c *CountSnapshot) record(s State) {
+	switch s {
+	case Running:
+		c.Running++
+	case Success:
+		c.Successes++
+	case Failure:
+		c.Failures++
+	case Cancelled:
+		c.Cancelled++
+	default:
+		c.Unknowns++
+	}
+}
+
+// counter is a Behavior which counts the results of another Behavior.
+type counter struct {
+	wrapper
+	counts *CountSnapshot
+}
+
+// Counter wraps a Behavior so that the results of its executions are counted
+// in the returned CountSnapshot, which is zeroed whenever the Behavior is
+// reset. Unlike Instrument, only the given Behavior is counted. The counts may
+// be read between executions, but not while the tree is executing.
+func Counter(b Behavior) (Behavior, *CountSnapshot) {
+	c := &counter{wrapper{node: b}, &CountSnapshot{}}
+	return c, c.counts
+}
+
+// Reset zeroes the counts and resets the underlying Behavior.
+func (c *counter) Reset() {
+	*c.counts = CountSnapshot{}
+	c.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, counting and returning the result.
+func (c *counter) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (c *counter) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, c.node)
+	c.counts.record(s)
+	return s
+}
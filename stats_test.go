@@ -0,0 +1,60 @@
+package bt
+
+import "testing"
+
+func TestInstrument(t *testing.T) {
+	first := Named("first", Recorded(Running, Success))
+	second := Named("second", Recorded(Failure))
+	root := Repeat(Sequence(first, second))
+	b, stats := Instrument(root)
+	expected := []State{Running, Running, Running, Running}
+	CheckBehavior("Instrument", t, b, expected)
+	b.Reset()
+	if len(stats.Nodes) != 6 {
+		t.Fatal("Instrument failed to instrument each node", len(stats.Nodes))
+	}
+	cases := []struct {
+		node     Behavior
+		expected NodeStats
+	}{
+		{root, NodeStats{Executes: 4, Running: 4, Resets: 1}},
+		{first, NodeStats{Executes: 4, Running: 2, Successes: 2, Resets: 3}},
+		{second, NodeStats{Executes: 2, Failures: 2, Resets: 3}},
+	}
+	for _, c := range cases {
+		actual := stats.Get(c.node)
+		if actual == nil {
+			t.Error("Instrument failed to record", NameOf(c.node))
+			continue
+		}
+		c.expected.Node = c.node
+		if *actual != c.expected {
+			t.Errorf("Instrument recorded incorrect stats for %s: %+v", NameOf(c.node), *actual)
+		}
+	}
+}
+
+func TestInstrument_Subtree(t *testing.T) {
+	sub, subStats := Instrument(Sequence(Recorded(Success)))
+	b, stats := Instrument(Invert(sub))
+	CheckBehavior("Instrument (Subtree)", t, b, []State{Failure})
+	if len(stats.Nodes) != 1 || len(subStats.Nodes) != 2 {
+		t.Error("Instrument re-instrumented subtree")
+	}
+	if subStats.Nodes[1].Successes != 1 {
+		t.Error("Instrument failed to record subtree execution")
+	}
+}
+
+func TestCounter(t *testing.T) {
+	b, counts := Counter(Recorded(Running, Success, Failure, Running, Unknown))
+	CheckBehavior("Counter", t, b, []State{Running, Success, Failure, Running, Unknown})
+	expected := CountSnapshot{Running: 2, Successes: 1, Failures: 1, Unknowns: 1}
+	if *counts != expected {
+		t.Error("Counter produced incorrect counts:", *counts)
+	}
+	b.Reset()
+	if *counts != (CountSnapshot{}) {
+		t.Error("Counter failed to zero counts on Reset:", *counts)
+	}
+}
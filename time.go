@@ -0,0 +1,268 @@
+package bt
+
+import (
+	"context"
+	"time"
+)
+
+// timeout is a Behavior which fails if another Behavior runs for too long.
+type timeout struct {
+	wrapper
+	d       time.Duration
+	clock   Clock
+	start   time.Time
+	started bool
+}
+
+// Timeout wraps a Behavior so that it fails if it is still Running after the
+// given duration has passed since its first execution. When the timeout fires,
+// the whole subtree is torn down: Abort reaches every Running descendant, so
+// that a blocking leaf such as an ActionStop is stopped, and then Reset
+// cascades through every descendant.
+func Timeout(b Behavior, d time.Duration) Behavior {
+	return TimeoutClock(b, d, SystemClock)
+}
+
+// TimeoutClock is like Timeout, but uses clock to get the current time.
+func TimeoutClock(b Behavior, d time.Duration, clock Clock) Behavior {
+	return &timeout{wrapper: wrapper{node: b}, d: d, clock: clock}
+}
+
+// Reset restarts the timer and resets the underlying Behavior.
+func (t *timeout) Reset() {
+	t.started = false
+	t.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior and returns the result, except that if
+// it is still Running past the deadline, it is aborted and reset, and Failure
+// is returned.
+func (t *timeout) Execute() State {
+	return Execute(context.Background(), t)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (t *timeout) ExecuteCtx(ctx context.Context) State {
+	if !t.started {
+		t.start = t.clock.Now()
+		t.started = true
+	}
+	s := Execute(ctx, t.node)
+	if s == Running && t.clock.Now().Sub(t.start) >= t.d {
+		interrupt(t.node)
+		return Failure
+	}
+	return s
+}
+
+// wait is a Behavior which waits for a duration to pass.
+type wait struct {
+	d       time.Duration
+	clock   Clock
+	start   time.Time
+	started bool
+}
+
+// Wait gets a Behavior which is Running until the given duration has passed
+// since its first execution, and then succeeds.
+func Wait(d time.Duration) Behavior {
+	return WaitClock(d, SystemClock)
+}
+
+// WaitClock is like Wait, but uses clock to get the current time.
+func WaitClock(d time.Duration, clock Clock) Behavior {
+	return &wait{d: d, clock: clock}
+}
+
+// Reset restarts the timer.
+func (w *wait) Reset() {
+	w.started = false
+}
+
+// Execute returns Running until the duration has passed, and Success after.
+func (w *wait) Execute() State {
+	if !w.started {
+		w.start = w.clock.Now()
+		w.started = true
+	}
+	if w.clock.Now().Sub(w.start) >= w.d {
+		return Success
+	}
+	return Running
+}
+
+// cooldownDuration is a Behavior which prevents another Behavior from running
+// again too soon after it succeeds.
+type cooldownDuration struct {
+	wrapper
+	d       time.Duration
+	clock   Clock
+	last    time.Time
+	cooling bool
+}
+
+// CooldownDuration wraps a Behavior so that after it succeeds, it fails without
+// being executed until the given duration has passed.
+func CooldownDuration(b Behavior, d time.Duration) Behavior {
+	return CooldownDurationClock(b, d, SystemClock)
+}
+
+// CooldownDurationClock is like CooldownDuration, but uses clock to get the
+// current time.
+func CooldownDurationClock(b Behavior, d time.Duration, clock Clock) Behavior {
+	return &cooldownDuration{wrapper: wrapper{node: b}, d: d, clock: clock}
+}
+
+// Reset clears the cooldown and resets the underlying Behavior.
+func (c *cooldownDuration) Reset() {
+	c.cooling = false
+	c.wrapper.Reset()
+}
+
+// Execute fails while cooling down, and otherwise runs the underlying Behavior
+// and returns the result. On Success, the underlying Behavior is reset so it
+// can run again once the cooldown has elapsed.
+func (c *cooldownDuration) Execute() State {
+	return Execute(context.Background(), c)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (c *cooldownDuration) ExecuteCtx(ctx context.Context) State {
+	if c.cooling && c.clock.Now().Sub(c.last) < c.d {
+		return Failure
+	}
+	c.cooling = false
+	s := Execute(ctx, c.node)
+	if s == Success {
+		c.last = c.clock.Now()
+		c.cooling = true
+		c.node.Reset()
+	}
+	return s
+}
+
+// budgetLimit is a Behavior which fails once another Behavior has spent too
+// long executing.
+type budgetLimit struct {
+	wrapper
+	total, spent time.Duration
+	clock        Clock
+}
+
+// Budget wraps a Behavior so that the time spent executing it is added up
+// across executions, and once the total is used up, it fails. Unlike Timeout,
+// which limits the time which passes since the first execution, Budget only
+// counts time spent inside Execute, so it caps the effort of an activation
+// rather than its duration. If the underlying Behavior is still Running once
+// the budget is used up, it is aborted and reset, and Failure is returned.
+// Reset restores the full budget.
+func Budget(b Behavior, total time.Duration) Behavior {
+	return BudgetClock(b, total, SystemClock)
+}
+
+// BudgetClock is like Budget, but uses clock to measure the time spent.
+func BudgetClock(b Behavior, total time.Duration, clock Clock) Behavior {
+	return &budgetLimit{wrapper: wrapper{node: b}, total: total, clock: clock}
+}
+
+// Reset restores the full budget and resets the underlying Behavior.
+func (b *budgetLimit) Reset() {
+	b.spent = 0
+	b.wrapper.Reset()
+}
+
+// Execute fails if the budget is used up, and otherwise runs the underlying
+// Behavior and returns the result, except that if it is still Running once the
+// budget is used up, it is aborted and reset, and Failure is returned.
+func (b *budgetLimit) Execute() State {
+	return Execute(context.Background(), b)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (b *budgetLimit) ExecuteCtx(ctx context.Context) State {
+	if b.spent >= b.total {
+		return Failure
+	}
+	start := b.clock.Now()
+	s := Execute(ctx, b.node)
+	b.spent += b.clock.Now().Sub(start)
+	if s == Running && b.spent >= b.total {
+		interrupt(b.node)
+		return Failure
+	}
+	return s
+}
+
+// timedSequence is a Behavior which is the conjunction of child Behavior, each
+// with its own time limit.
+type timedSequence struct {
+	composite
+	timeouts []time.Duration
+	clock    Clock
+	start    time.Time
+	timed    int
+}
+
+// TimedSequence gets a Behavior like Sequence, except that each child is given
+// the corresponding duration in timeouts, measured from when that child first
+// becomes the current child. A child which is still Running past its timeout
+// is aborted and reset, and the TimedSequence fails, as if each child were
+// wrapped with Timeout. It panics unless there is exactly one timeout per
+// child.
+func TimedSequence(timeouts []time.Duration, bs ...Behavior) Behavior {
+	return TimedSequenceClock(SystemClock, timeouts, bs...)
+}
+
+// TimedSequenceClock is like TimedSequence, but uses clock to get the current
+// time.
+func TimedSequenceClock(clock Clock, timeouts []time.Duration, bs ...Behavior) Behavior {
+	if len(timeouts) != len(bs) {
+		panic("bt: TimedSequence requires exactly one timeout per child")
+	}
+	return &timedSequence{
+		composite: composite{nodes: bs},
+		timeouts:  append([]time.Duration(nil), timeouts...),
+		clock:     clock,
+		timed:     -1,
+	}
+}
+
+// Reset restarts the timers and resets all child Behavior.
+func (s *timedSequence) Reset() {
+	s.timed = -1
+	s.composite.Reset()
+}
+
+// Execute runs each child Behavior in sequence. It succeeds if all the child
+// Behavior succeed, but fails if any child fails or runs past its timeout.
+func (s *timedSequence) Execute() State {
+	return Execute(context.Background(), s)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the child Behavior.
+func (s *timedSequence) ExecuteCtx(ctx context.Context) State {
+	for ; s.index < len(s.nodes); s.index++ {
+		if s.timed != s.index {
+			s.start = s.clock.Now()
+			s.timed = s.index
+		}
+		n := s.nodes[s.index]
+		switch r := Execute(ctx, n); r {
+		case Success:
+			continue
+		case Failure:
+			return Failure
+		case Running:
+			if s.clock.Now().Sub(s.start) >= s.timeouts[s.index] {
+				interrupt(n)
+				return Failure
+			}
+			return Running
+		case Cancelled:
+			return Cancelled
+		default:
+			return Unknown
+		}
+	}
+	return Success
+}
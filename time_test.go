@@ -0,0 +1,151 @@
+package bt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeNow gets a Clock which advances by step on each call.
+func fakeNow(step time.Duration) Clock {
+	now := time.Unix(0, 0)
+	return ClockFunc(func() time.Time {
+		t := now
+		now = now.Add(step)
+		return t
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running)}
+	b := TimeoutClock(wrapped, 3*time.Second, fakeNow(time.Second))
+	expected := []State{Running, Running, Failure}
+	CheckBehavior("Timeout", t, b, expected)
+	if wrapped.resets != 1 {
+		t.Error("Timeout failed to reset wrapped Behavior", wrapped.resets)
+	}
+	b.Reset()
+	CheckBehavior("Timeout (Reset)", t, b, []State{Running})
+}
+
+func TestTimeout_Complete(t *testing.T) {
+	b := TimeoutClock(Recorded(Running, Failure), 3*time.Second, fakeNow(time.Second))
+	CheckBehavior("Timeout (Complete)", t, b, []State{Running, Failure})
+	b = TimeoutClock(Recorded(Running, Success), 3*time.Second, fakeNow(time.Second))
+	CheckBehavior("Timeout (Complete)", t, b, []State{Running, Success})
+}
+
+func TestWait(t *testing.T) {
+	b := WaitClock(3*time.Second, fakeNow(time.Second))
+	expected := []State{Running, Running, Success, Success}
+	CheckBehavior("Wait", t, b, expected)
+	b.Reset()
+	CheckBehavior("Wait (Reset)", t, b, expected)
+}
+
+func TestCooldownDuration(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	wrapped := &testBehavior{base: Recorded(Success)}
+	b := CooldownDurationClock(wrapped, 3*time.Second, clock)
+	expected := []State{Success, Failure, Failure, Success, Failure}
+	actual := make([]State, len(expected))
+	for i := range expected {
+		actual[i] = b.Execute()
+		clock.Advance(time.Second)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Error("CooldownDuration produced incorrect states:", actual)
+	}
+	if wrapped.calls != 2 {
+		t.Error("CooldownDuration executed wrapped Behavior during cooldown", wrapped.calls)
+	}
+	b.Reset()
+	CheckBehavior("CooldownDuration (Reset)", t, b, []State{Success})
+}
+
+func TestManualClock(t *testing.T) {
+	start := time.Unix(10, 0)
+	clock := NewManualClock(start)
+	b := WaitClock(2*time.Second, clock)
+	CheckBehavior("Wait (ManualClock)", t, b, []State{Running, Running})
+	clock.Advance(time.Second)
+	CheckBehavior("Wait (ManualClock)", t, b, []State{Running})
+	clock.Advance(time.Second)
+	CheckBehavior("Wait (ManualClock)", t, b, []State{Success})
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Error("ManualClock failed to set time:", clock.Now())
+	}
+}
+
+func TestBudget(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	wrapped := &testBehavior{base: Action(func() State {
+		clock.Advance(time.Second)
+		return Running
+	})}
+	b := BudgetClock(wrapped, 3*time.Second, clock)
+	clock.Advance(time.Hour)
+	CheckBehavior("Budget", t, b, []State{Running, Running})
+	clock.Advance(time.Hour)
+	CheckBehavior("Budget", t, b, []State{Failure, Failure})
+	if wrapped.calls != 3 || wrapped.resets != 1 {
+		t.Error("Budget failed to stop wrapped Behavior once spent", wrapped.calls, wrapped.resets)
+	}
+	b.Reset()
+	CheckBehavior("Budget (Reset)", t, b, []State{Running})
+}
+
+func TestTimedSequence(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	second := &testBehavior{base: Recorded(Running)}
+	timeouts := []time.Duration{2 * time.Second, 3 * time.Second}
+	b := TimedSequenceClock(clock, timeouts, Recorded(Running, Success), second)
+	CheckBehavior("TimedSequence", t, b, []State{Running})
+	clock.Advance(5 * time.Second)
+	CheckBehavior("TimedSequence", t, b, []State{Running})
+	clock.Advance(2 * time.Second)
+	CheckBehavior("TimedSequence", t, b, []State{Running})
+	clock.Advance(time.Second)
+	CheckBehavior("TimedSequence", t, b, []State{Failure})
+	if second.calls != 3 || second.resets != 1 {
+		t.Error("TimedSequence failed to time out child", second.calls, second.resets)
+	}
+	b = TimedSequenceClock(clock, timeouts, Recorded(Success), Recorded(Running, Success))
+	CheckBehavior("TimedSequence (Success)", t, b, []State{Running, Success})
+	defer func() {
+		if recover() == nil {
+			t.Error("TimedSequence failed to panic on mismatched timeouts")
+		}
+	}()
+	TimedSequence(timeouts, Recorded(Success))
+}
+
+func TestTimeout_Cascade(t *testing.T) {
+	exited := make(chan struct{})
+	started := false
+	blocking := ActionStop(func(stop <-chan struct{}) State {
+		if !started {
+			started = true
+			go func() {
+				<-stop
+				close(exited)
+			}()
+		}
+		return Running
+	})
+	inner := Selection(Recorded(Failure), blocking)
+	outer := Sequence(Recorded(Success), inner)
+	b, stats := Instrument(TimeoutClock(outer, 2*time.Second, fakeNow(time.Second)))
+	CheckBehavior("Timeout (Cascade)", t, b, []State{Running, Failure})
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout failed to abort blocking leaf")
+	}
+	for _, n := range stats.Nodes[1:] {
+		if n.Resets != 1 {
+			t.Errorf("Timeout reset %s %d times", NameOf(n.Node), n.Resets)
+		}
+	}
+}
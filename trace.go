@@ -0,0 +1,188 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+)
+
+// tracer is called with each Behavior run by Execute and its result.
+var tracer func(node Behavior, result State)
+
+// SetTracer sets a function to be called after each Behavior in a tree is
+// executed, with the Behavior and the State it returned. Since composites and
+// decorators run themselves and their children with Execute, every node is
+// traced, except a leaf whose own Execute method is called directly. Use NameOf
+// to identify the node. Passing nil disables tracing, which is the
+// default and has no overhead. SetTracer must not be called while any tree is
+// executing.
+func SetTracer(fn func(node Behavior, result State)) {
+	tracer = fn
+}
+
+// onChange is a Behavior which reports changes in the result of another
+// Behavior.
+type onChange struct {
+	wrapper
+	fn   func(old, new State)
+	prev State
+}
+
+// OnChange wraps a Behavior so that fn is called whenever its result differs
+// from the previous result, before the result is returned. The previous result
+// is Unknown at first and after Reset, so the first execution is reported
+// unless it returns Unknown.
+func OnChange(b Behavior, fn func(old, new State)) Behavior {
+	return &onChange{wrapper: wrapper{node: b}, fn: fn}
+}
+
+// Reset forgets the previous result and resets the underlying Behavior.
+func (o *onChange) Reset() {
+	o.prev = Unknown
+	o.wrapper.Reset()
+}
+
+// Execute runs the underlying Behavior, reporting the result if it changed,
+// and returns the result.
+func (o *onChange) Execute() State {
+	return Execute(context.Background(), o)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (o *onChange) ExecuteCtx(ctx context.Context) State {
+	s := Execute(ctx, o.node)
+	if s != o.prev {
+		old := o.prev
+		o.prev = s
+		o.fn(old, s)
+	}
+	return s
+}
+
+// treeTrace collects the Behavior visited during one execution of a traced
+// tree, and passes them to done, if any, once the execution of the root
+// completes. The lines are kept until the next execution of the root, or until
+// the root is Reset.
+type treeTrace struct {
+	root  *traced
+	depth int
+	lines []traceLine
+	done  func([]traceLine)
+}
+
+// traceTree wraps each Behavior in the tree rooted at root so that each
+// execution is traced, passing the visited Behavior to done.
+func traceTree(root Behavior, done func([]traceLine)) Behavior {
+	t := &treeTrace{done: done}
+	t.root = t.wrap(root).(*traced)
+	return t.root
+}
+
+// traceLine is a Behavior visited during an execution, and its result.
+type traceLine struct {
+	depth  int
+	node   Behavior
+	result State
+}
+
+// TraceTree wraps each Behavior in the tree rooted at root so that every
+// execution of the root writes an indented trace of the Behavior it visited to
+// the returned buffer, one line per Behavior giving its NameOf and result, with
+// children indented two spaces beneath their parent. The buffer is cleared at
+// the start of each execution of the root, so it always holds the trace of the
+// latest tick. Like Instrument, the tree is modified in place, so only the
+// returned Behavior should be used afterwards.
+func TraceTree(root Behavior) (Behavior, *bytes.Buffer) {
+	buf := new(bytes.Buffer)
+	return traceTree(root, func(lines []traceLine) {
+		buf.Reset()
+		for _, l := range lines {
+			for k := 0; k < l.depth; k++ {
+				buf.WriteString("  ")
+			}
+			buf.WriteString(NameOf(l.node))
+			buf.WriteString(": ")
+			buf.WriteString(l.result.String())
+			buf.WriteByte('\n')
+		}
+	}), buf
+}
+
+// TracePath wraps each Behavior in the tree rooted at root so that LastPath can
+// report which Behavior were executed. Like Instrument, the tree is modified in
+// place, so only the returned Behavior should be used afterwards.
+func TracePath(root Behavior) Behavior {
+	return traceTree(root, nil)
+}
+
+// LastPath gets the Behavior executed during the most recent execution of root,
+// in the order in which they were started, beginning with root itself. The
+// path is cleared when root is Reset. It requires root to have been returned by
+// TracePath, TraceTree, or Recorder, so that trees which are not traced pay
+// nothing for it, and it returns nil otherwise. The Behavior returned are those
+// of the original tree, not the wrappers added to trace them.
+func LastPath(root Behavior) []Behavior {
+	t, ok := root.(*traced)
+	if !ok || t.trace.root != t {
+		return nil
+	}
+	var path []Behavior
+	for _, l := range t.trace.lines {
+		path = append(path, l.node)
+	}
+	return path
+}
+
+// wrap wraps b and each of its children, if they can be replaced. The children
+// of a ParallelAsync are not wrapped, since they run concurrently.
+func (t *treeTrace) wrap(b Behavior) Behavior {
+	if _, ok := b.(*parallelAsync); ok {
+		return &traced{wrapper{node: b}, t}
+	}
+	if r, ok := b.(replacer); ok {
+		for i, child := range b.(Composite).Children() {
+			if child != nil {
+				r.replace(i, t.wrap(child))
+			}
+		}
+	}
+	return &traced{wrapper{node: b}, t}
+}
+
+// traced is a Behavior which traces the executions of another Behavior.
+type traced struct {
+	wrapper
+	trace *treeTrace
+}
+
+// Execute runs the underlying Behavior, tracing and returning the result.
+func (t *traced) Execute() State {
+	return Execute(context.Background(), t)
+}
+
+// ExecuteCtx is like Execute, but passes ctx to the underlying Behavior.
+func (t *traced) ExecuteCtx(ctx context.Context) State {
+	tr := t.trace
+	depth := tr.depth
+	if depth == 0 {
+		tr.lines = tr.lines[:0]
+	}
+	i := len(tr.lines)
+	tr.lines = append(tr.lines, traceLine{depth: depth, node: t.node})
+	tr.depth++
+	defer func() { tr.depth = depth }()
+	s := Execute(ctx, t.node)
+	tr.lines[i].result = s
+	if depth == 0 && tr.done != nil {
+		tr.done(tr.lines)
+	}
+	return s
+}
+
+// Reset resets the underlying Behavior, and clears the trace if this is the
+// root of the traced tree.
+func (t *traced) Reset() {
+	t.wrapper.Reset()
+	if t.trace.root == t {
+		t.trace.lines = t.trace.lines[:0]
+	}
+}
@@ -0,0 +1,118 @@
+package bt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetTracer(t *testing.T) {
+	var trace []string
+	SetTracer(func(node Behavior, result State) {
+		trace = append(trace, fmt.Sprintf("%s: %v", NameOf(node), result))
+	})
+	defer SetTracer(nil)
+	b := Named("root", Sequence(
+		Named("check", Conditional(func() bool { return true })),
+		Named("act", Recorded(Running)),
+	))
+	Execute(context.Background(), b)
+	expected := []string{
+		"Conditional: Success",
+		"check: Success",
+		"Action: Running",
+		"act: Running",
+		"Sequence: Running",
+		"root: Running",
+	}
+	if !reflect.DeepEqual(expected, trace) {
+		t.Error("SetTracer produced incorrect trace:", trace)
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	var changes []string
+	b := OnChange(Recorded(Running, Running, Success, Success, Running), func(old, new State) {
+		changes = append(changes, fmt.Sprintf("%v->%v", old, new))
+	})
+	CheckBehavior("OnChange", t, b, []State{Running, Running, Success, Success, Running})
+	b.Reset()
+	CheckBehavior("OnChange (Reset)", t, b, []State{Running})
+	expected := []string{
+		"Unknown->Running", "Running->Success", "Success->Running",
+		"Unknown->Running",
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Error("OnChange reported incorrect changes:", changes)
+	}
+}
+
+func TestTraceTree(t *testing.T) {
+	root, buf := TraceTree(Named("root", Sequence(
+		Selection(Recorded(Failure), Recorded(Success)),
+		Named("check", Recorded(Running, Failure)),
+		Recorded(Success),
+	)))
+	root.Execute()
+	root.Execute()
+	expected := "root: Failure\n" +
+		"  Sequence: Failure\n" +
+		"    check: Failure\n" +
+		"      Action: Failure\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("TraceTree produced incorrect trace:\n%s", actual)
+	}
+}
+
+func TestTraceTree_Panic(t *testing.T) {
+	fail := true
+	root, buf := TraceTree(Sequence(Action(func() State {
+		if fail {
+			panic("fail")
+		}
+		return Success
+	})))
+	func() {
+		defer func() { recover() }()
+		root.Execute()
+	}()
+	fail = false
+	root.Execute()
+	expected := "Sequence: Success\n" +
+		"  Action: Success\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("TraceTree produced incorrect trace after panic:\n%s", actual)
+	}
+}
+
+func TestLastPath(t *testing.T) {
+	tree := ReactiveSelection(
+		Sequence(Named("guard", Recorded(Failure, Success)), Named("body", Recorded(Running))),
+		Named("fallback", Recorded(Running)),
+	)
+	root := TracePath(tree)
+	check := func(name string, expected ...string) {
+		t.Helper()
+		var actual []string
+		for _, b := range LastPath(root) {
+			actual = append(actual, NameOf(b))
+		}
+		if strings.Join(actual, " ") != strings.Join(expected, " ") {
+			t.Errorf("LastPath produced incorrect path %s: %v", name, actual)
+		}
+	}
+	root.Execute()
+	check("(Fallback)", "ReactiveSelection", "Sequence", "guard", "Action", "fallback", "Action")
+	root.Execute()
+	check("(Preempted)", "ReactiveSelection", "Sequence", "guard", "Action", "body", "Action")
+	if path := LastPath(root); path[0] != tree {
+		t.Error("LastPath produced wrapper instead of original root:", path[0])
+	}
+	root.Reset()
+	check("(Reset)")
+	if path := LastPath(tree); path != nil {
+		t.Error("LastPath produced path for untraced tree:", path)
+	}
+}
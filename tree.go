@@ -0,0 +1,343 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Namer is a Behavior with a name.
+type Namer interface {
+	Name() string
+}
+
+// Composite is a Behavior composed of child Behavior.
+type Composite interface {
+	Children() []Behavior
+}
+
+// Decorated is a Behavior which wraps a single child Behavior.
+type Decorated interface {
+	Child() Behavior
+}
+
+// Children gets the child Behavior.
+func (c *composite) Children() []Behavior {
+	return c.nodes
+}
+
+// Children gets the child Behavior.
+func (c *pcomposite) Children() []Behavior {
+	return c.nodes
+}
+
+// Children gets the underlying Behavior as the only child.
+func (w *wrapper) Children() []Behavior {
+	return []Behavior{w.node}
+}
+
+// Child gets the underlying Behavior.
+func (w *wrapper) Child() Behavior {
+	return w.node
+}
+
+// unwrap gets the first Behavior of type T found by following the Decorated
+// chain from b, so that a Behavior can still be recognized after it has been
+// wrapped, such as by Named or Instrument.
+func unwrap[T Behavior](b Behavior) (T, bool) {
+	for b != nil {
+		if t, ok := b.(T); ok {
+			return t, true
+		}
+		d, ok := b.(Decorated)
+		if !ok {
+			break
+		}
+		b = d.Child()
+	}
+	var zero T
+	return zero, false
+}
+
+// Children gets the underlying Behavior as the only child, or no children if
+// it has not been obtained yet.
+func (r *ref) Children() []Behavior {
+	if r.node == nil {
+		return nil
+	}
+	return r.wrapper.Children()
+}
+
+// Stateful is a Behavior which remembers the State it last returned, so that
+// a tree can be inspected without executing it again. All composites and
+// decorators are Stateful; a leaf can be made Stateful by wrapping it with
+// Named. The State is recorded whenever the Behavior is executed, whether by
+// its own Execute method or by the package Execute, which composites and
+// decorators use to run their children. The State is Unknown until then, and
+// again after Reset.
+type Stateful interface {
+	State() State
+}
+
+// stateSetter is a Behavior which records the State it last returned.
+type stateSetter interface {
+	setState(s State)
+}
+
+// State gets the State last returned.
+func (c *composite) State() State {
+	return c.state
+}
+
+// setState records the State last returned.
+func (c *composite) setState(s State) {
+	c.state = s
+}
+
+// State gets the State last returned.
+func (c *pcomposite) State() State {
+	return c.state
+}
+
+// setState records the State last returned.
+func (c *pcomposite) setState(s State) {
+	c.state = s
+}
+
+// State gets the State last returned.
+func (w *wrapper) State() State {
+	return w.state
+}
+
+// setState records the State last returned.
+func (w *wrapper) setState(s State) {
+	w.state = s
+}
+
+// Progress is a Behavior which can report how far it has got through its
+// children. For a composite which runs its children in order, such as Sequence
+// or Selection, current is the index of the child it stopped at, which is the
+// number of children it has passed, and equals total once every child has been
+// passed. Reactive composites report the child reached on the last execution.
+// For a parallel composite, current is the number of children which have
+// completed. Reporting progress does not execute anything.
+type Progress interface {
+	Progress() (current, total int)
+}
+
+// Progress gets the index of the current child and the number of children.
+func (c *composite) Progress() (current, total int) {
+	return c.index, len(c.nodes)
+}
+
+// Progress gets the number of completed children and the number of children.
+func (c *pcomposite) Progress() (current, total int) {
+	return len(c.complete), len(c.nodes)
+}
+
+// replacer is a Behavior whose children can be replaced.
+type replacer interface {
+	replace(i int, b Behavior)
+}
+
+// replace replaces the child at index i.
+func (c *composite) replace(i int, b Behavior) {
+	c.nodes[i] = b
+}
+
+// replace replaces the child at index i.
+func (c *pcomposite) replace(i int, b Behavior) {
+	c.nodes[i] = b
+}
+
+// replace replaces the underlying Behavior.
+func (w *wrapper) replace(_ int, b Behavior) {
+	w.node = b
+}
+
+// Walk calls fn for each non-nil Behavior in the tree rooted at root, visiting
+// each parent before its children.
+func Walk(root Behavior, fn func(Behavior)) {
+	if root == nil {
+		return
+	}
+	fn(root)
+	if c, ok := root.(Composite); ok {
+		for _, child := range c.Children() {
+			Walk(child, fn)
+		}
+	}
+}
+
+// Size gets the number of non-nil Behavior in the tree rooted at root.
+func Size(root Behavior) int {
+	n := 0
+	measure(root, 1, func(int) { n++ })
+	return n
+}
+
+// Depth gets the number of Behavior on the longest path from root to a leaf.
+func Depth(root Behavior) int {
+	d := 0
+	measure(root, 1, func(depth int) { d = max(d, depth) })
+	return d
+}
+
+// measure calls fn with the depth of b and each of its descendants, skipping
+// nil Behavior.
+func measure(b Behavior, depth int, fn func(depth int)) {
+	if b == nil {
+		return
+	}
+	fn(depth)
+	if c, ok := b.(Composite); ok {
+		for _, child := range c.Children() {
+			measure(child, depth+1, fn)
+		}
+	}
+}
+
+// Validate gets an error listing, by path, each structural mistake in the tree
+// rooted at root, such as a nil Behavior or an empty composite, or nil if there
+// are none.
+func Validate(root Behavior) error {
+	var errs []error
+	var visit func(b Behavior, path string)
+	visit = func(b Behavior, path string) {
+		if b == nil {
+			errs = append(errs, fmt.Errorf("bt: %s: nil Behavior", path))
+			return
+		}
+		if w, ok := b.(*weightedSelection); ok && len(w.weights) != len(w.all) {
+			errs = append(errs, fmt.Errorf("bt: %s: %d weights for %d children", path, len(w.weights), len(w.all)))
+		}
+		c, ok := b.(Composite)
+		if !ok {
+			return
+		}
+		children := c.Children()
+		if _, ok := b.(Decorated); !ok && len(children) == 0 {
+			errs = append(errs, fmt.Errorf("bt: %s: composite without children", path))
+		}
+		for i, child := range children {
+			name := "nil"
+			if child != nil {
+				name = NameOf(child)
+			}
+			visit(child, fmt.Sprintf("%s/%s[%d]", path, name, i))
+		}
+	}
+	name := "nil"
+	if root != nil {
+		name = NameOf(root)
+	}
+	visit(root, name)
+	return errors.Join(errs...)
+}
+
+// NonEmpty returns b, but panics if b is a composite without children. It is
+// intended to wrap composite constructors for those who would rather fail fast
+// than rely on the defined results of empty composites, which succeed for
+// conjunctions such as Sequence and fail for disjunctions such as Selection.
+func NonEmpty(b Behavior) Behavior {
+	if c, ok := b.(Composite); ok && len(c.Children()) == 0 {
+		if _, ok := b.(Decorated); !ok {
+			panic(fmt.Sprintf("bt: %s requires at least one child", NameOf(b)))
+		}
+	}
+	return b
+}
+
+// NameOf gets the name of b if it is a Namer, or the capitalized name of its
+// type otherwise. It does not execute b.
+func NameOf(b Behavior) string {
+	if n, ok := b.(Namer); ok {
+		return n.Name()
+	}
+	t := reflect.TypeOf(b)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if name == "" {
+		return t.String()
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// named is a Behavior which attaches a name to another Behavior.
+type named struct {
+	wrapper
+	name string
+}
+
+// Named wraps a Behavior to give it a name. The Behavior is otherwise
+// unchanged.
+func Named(name string, b Behavior) Behavior {
+	return &named{wrapper{node: b}, name}
+}
+
+// Name gets the name given to the underlying Behavior.
+func (n *named) Name() string {
+	return n.name
+}
+
+// Execute runs the underlying Behavior and returns the result.
+func (n *named) Execute() State {
+	return Execute(context.Background(), n)
+}
+
+// ExecuteCtx runs the underlying Behavior with ctx and returns the result.
+func (n *named) ExecuteCtx(ctx context.Context) State {
+	return Execute(ctx, n.node)
+}
+
+// Tagged is a Behavior annotated with tags, such as by Tag.
+type Tagged interface {
+	Tags() []string
+}
+
+// tagged is a Behavior which attaches tags to another Behavior.
+type tagged struct {
+	wrapper
+	tags []string
+}
+
+// Tag wraps a Behavior to annotate it with tags, so that tooling can find it
+// with FindByTag. Tags are metadata only; the Behavior is otherwise unchanged.
+func Tag(b Behavior, tags ...string) Behavior {
+	return &tagged{wrapper{node: b}, append([]string(nil), tags...)}
+}
+
+// Tags gets a copy of the tags given to the underlying Behavior.
+func (t *tagged) Tags() []string {
+	return append([]string(nil), t.tags...)
+}
+
+// Execute runs the underlying Behavior and returns the result.
+func (t *tagged) Execute() State {
+	return Execute(context.Background(), t)
+}
+
+// ExecuteCtx runs the underlying Behavior with ctx and returns the result.
+func (t *tagged) ExecuteCtx(ctx context.Context) State {
+	return Execute(ctx, t.node)
+}
+
+// FindByTag gets each Tagged Behavior in the tree rooted at root with the tag.
+func FindByTag(root Behavior, tag string) []Behavior {
+	var found []Behavior
+	Walk(root, func(b Behavior) {
+		if t, ok := b.(Tagged); ok {
+			for _, s := range t.Tags() {
+				if s == tag {
+					found = append(found, b)
+					break
+				}
+			}
+		}
+	})
+	return found
+}
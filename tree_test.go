@@ -0,0 +1,211 @@
+package bt
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNamed(t *testing.T) {
+	wrapped := &testBehavior{base: Recorded(Running, Failure)}
+	b := Named("wrapped", wrapped)
+	CheckBehavior("Named", t, b, []State{Running, Failure})
+	b.Reset()
+	if wrapped.resets != 1 {
+		t.Error("Named failed to reset wrapped Behavior")
+	}
+	if name := NameOf(b); name != "wrapped" {
+		t.Error("Named produced incorrect name:", name)
+	}
+}
+
+func TestNameOf(t *testing.T) {
+	b := Sequence(
+		Named("first", Recorded(Success)),
+		&testBehavior{base: Recorded(Success)},
+		Named("last", Recorded(Success)),
+	)
+	var names []string
+	for _, c := range b.(Composite).Children() {
+		names = append(names, NameOf(c))
+	}
+	expected := []string{"first", "TestBehavior", "last"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Error("NameOf produced incorrect names:", names)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	b := Selection(
+		Named("a", Invert(Recorded(Success))),
+		PSequence(Named("b", Recorded(Success)), Named("c", Recorded(Success))),
+	)
+	var names []string
+	Walk(b, func(n Behavior) {
+		names = append(names, NameOf(n))
+	})
+	expected := []string{
+		"Selection", "a", "Invert", "Action",
+		"Parallel", "b", "Action", "c", "Action",
+	}
+	if !reflect.DeepEqual(expected, names) {
+		t.Error("Walk visited incorrect nodes:", names)
+	}
+}
+
+func TestDecorated(t *testing.T) {
+	child := Recorded(Success)
+	b := Named("named", Until(child))
+	inner := b.(Decorated).Child()
+	if NameOf(inner) != "Until" {
+		t.Error("Decorated produced incorrect child:", NameOf(inner))
+	}
+	if _, ok := inner.(Decorated).Child().(Action); !ok {
+		t.Error("Decorated produced incorrect grandchild")
+	}
+}
+
+func TestSizeDepth(t *testing.T) {
+	b := Selection(
+		Named("a", Invert(Recorded(Success))),
+		PSequence(Named("b", Recorded(Success)), Named("c", Recorded(Success))),
+	)
+	if size := Size(b); size != 9 {
+		t.Error("Size produced incorrect count:", size)
+	}
+	if depth := Depth(b); depth != 4 {
+		t.Error("Depth produced incorrect depth:", depth)
+	}
+	if Size(Recorded(Success)) != 1 || Depth(Recorded(Success)) != 1 {
+		t.Error("Size or Depth produced incorrect result for leaf")
+	}
+	if Size(nil) != 0 || Depth(nil) != 0 {
+		t.Error("Size or Depth produced incorrect result for nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	leaf := Recorded(Success)
+	if err := Validate(Sequence(leaf, Invert(leaf))); err != nil {
+		t.Error("Validate reported error for valid tree:", err)
+	}
+	weighted := &weightedSelection{ordered: newOrdered([]Behavior{leaf}), weights: []float64{1, 2}}
+	b := Selection(leaf, Sequence(Invert(nil), PSequence()), weighted)
+	err := Validate(b)
+	if err == nil {
+		t.Fatal("Validate failed to report errors")
+	}
+	expected := "bt: Selection/Sequence[1]/Invert[0]/nil[0]: nil Behavior\n" +
+		"bt: Selection/Sequence[1]/Parallel[1]: composite without children\n" +
+		"bt: Selection/WeightedSelection[2]: 2 weights for 1 children"
+	if err.Error() != expected {
+		t.Error("Validate reported incorrect errors:", err)
+	}
+}
+
+func TestNonEmpty(t *testing.T) {
+	b := Sequence(Recorded(Success))
+	if NonEmpty(b) != b {
+		t.Error("NonEmpty failed to return composite with children")
+	}
+	if leaf := Action(nil); NonEmpty(leaf) == nil {
+		t.Error("NonEmpty failed to return leaf")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NonEmpty failed to panic on empty composite")
+		}
+	}()
+	NonEmpty(Selection())
+}
+
+func TestStateful(t *testing.T) {
+	leaf := Named("leaf", Recorded(Running, Success))
+	b := Sequence(Invert(Recorded(Failure)), PSequence(leaf), RepeatN(Recorded(Success), 2))
+	if actual := Execute(context.Background(), b); actual != Running {
+		t.Fatal("Sequence produced incorrect state:", actual)
+	}
+	Walk(b, func(n Behavior) {
+		s, ok := n.(Stateful)
+		if !ok {
+			return
+		}
+		var expected State
+		switch NameOf(n) {
+		case "Sequence", "Parallel", "leaf":
+			expected = Running
+		case "Invert":
+			expected = Success
+		}
+		if s.State() != expected {
+			t.Errorf("%s recorded incorrect state: %v", NameOf(n), s.State())
+		}
+	})
+	b.Reset()
+	Walk(b, func(n Behavior) {
+		if s, ok := n.(Stateful); ok && s.State() != Unknown {
+			t.Errorf("%s kept state after Reset: %v", NameOf(n), s.State())
+		}
+	})
+}
+
+func TestStateful_Root(t *testing.T) {
+	roots := []Behavior{
+		Sequence(Recorded(Running)),
+		ReactiveSelection(Recorded(Running)),
+		PSequence(Recorded(Running)),
+		Invert(Recorded(Running)),
+		Named("leaf", Recorded(Running)),
+		Timeout(Recorded(Running), time.Hour),
+	}
+	instrumented, _ := Instrument(Sequence(Recorded(Running)))
+	roots = append(roots, instrumented)
+	for _, b := range roots {
+		if actual := b.Execute(); actual != Running {
+			t.Fatalf("%s produced incorrect state: %v", NameOf(b), actual)
+		}
+		if s := b.(Stateful).State(); s != Running {
+			t.Errorf("%s failed to record state as root: %v", NameOf(b), s)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	b := Sequence(Recorded(Success), Recorded(Running, Success), Recorded(Success))
+	check := func(b Behavior, current, total int) {
+		c, n := b.(Progress).Progress()
+		if c != current || n != total {
+			t.Errorf("%s reported incorrect progress: %d of %d", NameOf(b), c, n)
+		}
+	}
+	check(b, 0, 3)
+	b.Execute()
+	check(b, 1, 3)
+	b.Execute()
+	check(b, 3, 3)
+	p := PSequence(Recorded(Success), Recorded(Running, Success))
+	p.Execute()
+	check(p, 1, 2)
+}
+
+func TestFindByTag(t *testing.T) {
+	attack := Tag(Recorded(Success), "combat")
+	flee := Tag(Recorded(Failure), "combat", "escape")
+	idle := Tag(Recorded(Success), "idle")
+	root := Tag(Selection(
+		Sequence(attack, Invert(flee)),
+		idle,
+	), "root")
+	CheckBehavior("Tag", t, root, []State{Success})
+	found := FindByTag(root, "combat")
+	if len(found) != 2 || found[0] != attack || found[1] != flee {
+		t.Error("FindByTag found incorrect Behavior:", found)
+	}
+	if found := FindByTag(root, "missing"); len(found) != 0 {
+		t.Error("FindByTag found Behavior for missing tag:", found)
+	}
+	if tags := flee.(Tagged).Tags(); len(tags) != 2 || tags[1] != "escape" {
+		t.Error("Tags produced incorrect tags:", tags)
+	}
+}
@@ -0,0 +1,109 @@
+package bt
+
+import "context"
+
+// TypedBehavior is a Behavior which is executed with a value of type T, such
+// as the state of an agent, which is shared with each node in the tree.
+type TypedBehavior[T any] interface {
+	CtxBehavior
+	ExecuteT(v T) State
+}
+
+// typedKey is the Context key for a value of type T.
+type typedKey[T any] struct{}
+
+// WithTyped gets a copy of ctx which carries v to the TypedBehavior executed
+// with it.
+func WithTyped[T any](ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, typedKey[T]{}, v)
+}
+
+// TypedFrom gets the value of type T carried by ctx, and whether there was one.
+func TypedFrom[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(typedKey[T]{}).(T)
+	return v, ok
+}
+
+// typed is a TypedBehavior which adapts an untyped Behavior.
+type typed[T any] struct {
+	wrapper
+}
+
+// Typed adapts a Behavior, such as a composite or decorator of TypedBehavior,
+// into a TypedBehavior.
+func Typed[T any](b Behavior) TypedBehavior[T] {
+	return &typed[T]{wrapper{node: b}}
+}
+
+// Name gets the name of the adapter.
+func (t *typed[T]) Name() string {
+	return "Typed"
+}
+
+// Execute runs the underlying Behavior and returns the result.
+func (t *typed[T]) Execute() State {
+	return Execute(context.Background(), t)
+}
+
+// ExecuteCtx runs the underlying Behavior with ctx and returns the result.
+func (t *typed[T]) ExecuteCtx(ctx context.Context) State {
+	return Execute(ctx, t.node)
+}
+
+// ExecuteT runs the underlying Behavior with v and returns the result.
+func (t *typed[T]) ExecuteT(v T) State {
+	return Execute(WithTyped(context.Background(), v), t)
+}
+
+// actionT is a function of a T which acts as a TypedBehavior.
+type actionT[T any] func(T) State
+
+// ActionT gets a TypedBehavior which calls fn with the value of type T it is
+// executed with, and returns the result. Executed without a T, it returns
+// Unknown.
+func ActionT[T any](fn func(T) State) TypedBehavior[T] {
+	return actionT[T](fn)
+}
+
+// Name gets the name of the action.
+func (actionT[T]) Name() string {
+	return "ActionT"
+}
+
+// Reset is a noop.
+func (actionT[T]) Reset() {}
+
+// Execute returns Unknown, since there is no T without a Context.
+func (actionT[T]) Execute() State { return Unknown }
+
+// ExecuteCtx calls the underlying function with the T carried by ctx and
+// returns the result. It returns Unknown if ctx carries no T.
+func (a actionT[T]) ExecuteCtx(ctx context.Context) State {
+	v, ok := TypedFrom[T](ctx)
+	if !ok {
+		return Unknown
+	}
+	return a(v)
+}
+
+// ExecuteT calls the underlying function with v and returns the result.
+func (a actionT[T]) ExecuteT(v T) State { return a(v) }
+
+// untyped gets each TypedBehavior as a Behavior.
+func untyped[T any](bs []TypedBehavior[T]) []Behavior {
+	nodes := make([]Behavior, len(bs))
+	for i, b := range bs {
+		nodes[i] = b
+	}
+	return nodes
+}
+
+// SequenceT is like Sequence, but for TypedBehavior.
+func SequenceT[T any](bs ...TypedBehavior[T]) TypedBehavior[T] {
+	return Typed[T](Sequence(untyped(bs)...))
+}
+
+// SelectionT is like Selection, but for TypedBehavior.
+func SelectionT[T any](bs ...TypedBehavior[T]) TypedBehavior[T] {
+	return Typed[T](Selection(untyped(bs)...))
+}
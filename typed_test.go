@@ -0,0 +1,49 @@
+package bt
+
+import (
+	"context"
+	"testing"
+)
+
+type agent struct {
+	health int
+	fled   bool
+}
+
+func TestTyped(t *testing.T) {
+	b := SelectionT(
+		SequenceT(
+			ActionT(func(a *agent) State {
+				if a.health < 10 {
+					return Success
+				}
+				return Failure
+			}),
+			ActionT(func(a *agent) State {
+				a.fled = true
+				return Success
+			}),
+		),
+		Typed[*agent](Invert(ActionT(func(a *agent) State { return Failure }))),
+	)
+	healthy := &agent{health: 100}
+	if actual := b.ExecuteT(healthy); actual != Success || healthy.fled {
+		t.Error("TypedBehavior produced incorrect state:", actual)
+	}
+	b.Reset()
+	wounded := &agent{health: 5}
+	if actual := b.ExecuteT(wounded); actual != Success || !wounded.fled {
+		t.Error("TypedBehavior failed to thread value to children:", actual)
+	}
+}
+
+func TestTyped_Missing(t *testing.T) {
+	b := SequenceT(ActionT(func(int) State { return Success }))
+	if actual := b.Execute(); actual != Unknown {
+		t.Error("TypedBehavior produced incorrect state without value:", actual)
+	}
+	b.Reset()
+	if actual := Execute(WithTyped(context.Background(), "wrong"), b); actual != Unknown {
+		t.Error("TypedBehavior produced incorrect state with wrong type:", actual)
+	}
+}